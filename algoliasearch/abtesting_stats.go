@@ -0,0 +1,142 @@
+package algoliasearch
+
+import (
+	"errors"
+	"math"
+)
+
+// trackedSearches returns the number of searches on which the click and
+// conversion rates of the variant are computed. Older AB Tests do not report
+// the tracked searches, in which case all the searches are used.
+func (v VariantResponse) trackedSearches() int {
+	if v.TrackedSearchCount > 0 {
+		return v.TrackedSearchCount
+	}
+	return v.SearchCount
+}
+
+// successes returns the given count of clicks or conversions capped to the
+// tracked searches of the variant. As a single search can be clicked or
+// converted several times, the counts can exceed the number of searches,
+// while the statistics below need the proportion of successful searches, in
+// [0, 1]. The API does not report the number of searches with at least one
+// click or conversion, so capping the counts is an approximation which is
+// exact as long as no search gets several clicks or conversions.
+func (v VariantResponse) successes(count int) int {
+	if trials := v.trackedSearches(); count > trials {
+		return trials
+	}
+	if count < 0 {
+		return 0
+	}
+	return count
+}
+
+// ClickThroughRateInterval returns the bounds of the Wilson score interval of
+// the click-through rate of the variant, at the given confidence level (such
+// as 0.95). Both bounds are zero if the variant has not been searched yet. The
+// clicks are capped to the tracked searches, as explained by `successes`.
+func (v VariantResponse) ClickThroughRateInterval(confidenceLevel float64) (low, high float64) {
+	return wilsonInterval(v.successes(v.ClickCount), v.trackedSearches(), confidenceLevel)
+}
+
+// ConversionRateInterval returns the bounds of the Wilson score interval of
+// the conversion rate of the variant, at the given confidence level (such as
+// 0.95). Both bounds are zero if the variant has not been searched yet. The
+// conversions are capped to the tracked searches, as explained by
+// `successes`.
+func (v VariantResponse) ConversionRateInterval(confidenceLevel float64) (low, high float64) {
+	return wilsonInterval(v.successes(v.ConversionCount), v.trackedSearches(), confidenceLevel)
+}
+
+// CompareClicks compares the click-through rates of the two variants of the
+// AB Test, from their click and tracked search counts. The confidence level
+// (such as 0.95) is the one of the interval of the difference between both
+// rates. `NotEnoughABTestDataErr` is returned if any variant has not been
+// searched yet. The clicks are capped to the tracked searches of each
+// variant, so that the rates stay within [0, 1].
+func (r ABTestResponse) CompareClicks(confidenceLevel float64) (ABTestComparison, error) {
+	return r.compare(confidenceLevel, func(v VariantResponse) int { return v.ClickCount })
+}
+
+// CompareConversions is the same as CompareClicks but it compares the
+// conversion rates of the variants instead.
+func (r ABTestResponse) CompareConversions(confidenceLevel float64) (ABTestComparison, error) {
+	return r.compare(confidenceLevel, func(v VariantResponse) int { return v.ConversionCount })
+}
+
+// compare runs a two-proportion z-test on the metric extracted by `count`
+// from the two variants of the AB Test.
+func (r ABTestResponse) compare(confidenceLevel float64, count func(VariantResponse) int) (c ABTestComparison, err error) {
+	if len(r.Variants) != 2 {
+		err = errors.New("AB Test comparison needs exactly 2 variants")
+		return
+	}
+	if confidenceLevel <= 0 || confidenceLevel >= 1 {
+		err = errors.New("AB Test comparison confidence level should be strictly between 0 and 1")
+		return
+	}
+
+	a, b := r.Variants[0], r.Variants[1]
+	xA, nA := float64(a.successes(count(a))), float64(a.trackedSearches())
+	xB, nB := float64(b.successes(count(b))), float64(b.trackedSearches())
+	if nA == 0 || nB == 0 {
+		err = NotEnoughABTestDataErr
+		return
+	}
+
+	c.RateA, c.RateB = xA/nA, xB/nB
+	c.Difference = c.RateB - c.RateA
+	if c.RateA == 0 {
+		c.Lift = math.NaN()
+	} else {
+		c.Lift = c.Difference / c.RateA
+	}
+
+	margin := normalQuantile(0.5+confidenceLevel/2) * math.Sqrt(c.RateA*(1-c.RateA)/nA+c.RateB*(1-c.RateB)/nB)
+	c.DifferenceLow, c.DifferenceHigh = c.Difference-margin, c.Difference+margin
+
+	pooled := (xA + xB) / (nA + nB)
+	stdErr := math.Sqrt(pooled * (1 - pooled) * (1/nA + 1/nB))
+	if stdErr == 0 {
+		// Both rates are either 0 or 1: there is no difference at all.
+		c.PValue = 1
+	} else {
+		c.PValue = math.Erfc(math.Abs(c.Difference) / stdErr / math.Sqrt2)
+	}
+
+	return
+}
+
+// wilsonInterval returns the Wilson score interval of the proportion of
+// `successes` out of `trials` at the given confidence level.
+func wilsonInterval(successes, trials int, confidenceLevel float64) (low, high float64) {
+	if trials <= 0 {
+		return
+	}
+
+	n := float64(trials)
+	p := float64(successes) / n
+	z := normalQuantile(0.5 + confidenceLevel/2)
+	z2 := z * z
+
+	center := (p + z2/(2*n)) / (1 + z2/n)
+	margin := z / (1 + z2/n) * math.Sqrt(p*(1-p)/n+z2/(4*n*n))
+
+	return math.Max(0, center-margin), math.Min(1, center+margin)
+}
+
+// normalQuantile returns the quantile of the standard normal distribution for
+// the probability `p`, by bisection of its cumulative distribution function.
+func normalQuantile(p float64) float64 {
+	low, high := -10.0, 10.0
+	for i := 0; i < 100; i++ {
+		mid := (low + high) / 2
+		if 0.5*math.Erfc(-mid/math.Sqrt2) < p {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+	return (low + high) / 2
+}
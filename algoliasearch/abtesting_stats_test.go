@@ -0,0 +1,94 @@
+package algoliasearch
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalQuantile(t *testing.T) {
+	require.InDelta(t, 0, normalQuantile(0.5), 1e-9)
+	require.InDelta(t, 1.959964, normalQuantile(0.975), 1e-6)
+	require.InDelta(t, -2.326348, normalQuantile(0.01), 1e-6)
+}
+
+func TestVariantResponse_ClickThroughRateInterval(t *testing.T) {
+	v := VariantResponse{ClickCount: 50, SearchCount: 200, TrackedSearchCount: 100}
+	low, high := v.ClickThroughRateInterval(0.95)
+	require.InDelta(t, 0.4038, low, 1e-4)
+	require.InDelta(t, 0.5962, high, 1e-4)
+
+	low, high = VariantResponse{ConversionCount: 0, SearchCount: 10}.ConversionRateInterval(0.95)
+	require.Equal(t, 0.0, low)
+	require.InDelta(t, 0.2775, high, 1e-4)
+
+	low, high = VariantResponse{}.ClickThroughRateInterval(0.95)
+	require.Equal(t, 0.0, low)
+	require.Equal(t, 0.0, high)
+}
+
+func TestABTestResponse_CompareClicks(t *testing.T) {
+	r := ABTestResponse{
+		Variants: []VariantResponse{
+			{ClickCount: 100, ConversionCount: 10, TrackedSearchCount: 1000},
+			{ClickCount: 130, ConversionCount: 10, TrackedSearchCount: 1000},
+		},
+	}
+
+	c, err := r.CompareClicks(0.95)
+	require.NoError(t, err)
+	require.InDelta(t, 0.1, c.RateA, 1e-9)
+	require.InDelta(t, 0.13, c.RateB, 1e-9)
+	require.InDelta(t, 0.03, c.Difference, 1e-9)
+	require.InDelta(t, 0.3, c.Lift, 1e-9)
+	require.InDelta(t, 0.0355, c.PValue, 1e-4)
+	require.True(t, c.DifferenceLow > 0 && c.DifferenceLow < c.Difference)
+	require.InDelta(t, c.Difference-c.DifferenceLow, c.DifferenceHigh-c.Difference, 1e-9)
+	require.True(t, c.Significant(0.95))
+	require.False(t, c.Significant(0.99))
+
+	c, err = r.CompareConversions(0.95)
+	require.NoError(t, err)
+	require.Equal(t, 0.0, c.Difference)
+	require.InDelta(t, 1, c.PValue, 1e-9)
+	require.False(t, c.Significant(0.95))
+
+	r.Variants[0].ClickCount = 0
+	c, err = r.CompareClicks(0.95)
+	require.NoError(t, err)
+	require.True(t, math.IsNaN(c.Lift))
+
+	r.Variants[1].TrackedSearchCount = 0
+	_, err = r.CompareClicks(0.95)
+	require.Equal(t, NotEnoughABTestDataErr, err)
+
+	_, err = ABTestResponse{Variants: r.Variants[:1]}.CompareClicks(0.95)
+	require.Error(t, err)
+
+	_, err = r.CompareClicks(95)
+	require.Error(t, err)
+}
+
+func TestABTestStats_moreClicksThanSearches(t *testing.T) {
+	v := VariantResponse{ClickCount: 150, TrackedSearchCount: 100}
+	low, high := v.ClickThroughRateInterval(0.95)
+	require.False(t, math.IsNaN(low) || math.IsNaN(high))
+	require.True(t, low > 0.9 && low <= 1)
+	require.Equal(t, 1.0, high)
+
+	r := ABTestResponse{
+		Variants: []VariantResponse{
+			{ClickCount: 50, TrackedSearchCount: 100},
+			{ClickCount: 150, TrackedSearchCount: 100},
+		},
+	}
+	c, err := r.CompareClicks(0.95)
+	require.NoError(t, err)
+	require.Equal(t, 0.5, c.RateA)
+	require.Equal(t, 1.0, c.RateB)
+	for _, f := range []float64{c.DifferenceLow, c.DifferenceHigh, c.PValue} {
+		require.False(t, math.IsNaN(f))
+	}
+	require.True(t, c.Significant(0.95))
+}
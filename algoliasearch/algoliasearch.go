@@ -1,6 +1,8 @@
 package algoliasearch
 
 import (
+	"context"
+	"io"
 	"net/http"
 	"time"
 )
@@ -35,6 +37,7 @@ type Client interface {
 	// all analytics requests to the Algolia Analytics API.
 	//
 	// Default value is controlled by algoliasearch.DefaultAnalyticsTimeout.
+	// The same timeout is used for the requests to the Algolia Insights API.
 	SetAnalyticsTimeout(t time.Duration)
 
 	// SetMaxIdleConnsPerHosts specifies the value for `MaxIdleConnsPerHost` of
@@ -46,6 +49,44 @@ type Client interface {
 	// working if the underlying transport is not of type *http.Transport.
 	SetHTTPClient(client *http.Client)
 
+	// SetWaitPolicy specifies how WaitTask and WaitTasks poll the status of
+	// tasks: initial and maximum intervals between two polls, jitter and
+	// overall timeout.
+	//
+	// Default value is controlled by algoliasearch.DefaultWaitPolicy.
+	SetWaitPolicy(policy WaitPolicy)
+
+	// SetResponseCache enables the caching of the responses of the `Search`,
+	// `MultipleQueries` and `SearchForFacetValues` methods into the given
+	// `cache`, such as an LRUResponseCache. Responses are cached per index,
+	// parameters and headers. A nil `cache` disables the caching, which is
+	// the default. The cache can be bypassed for a single request with
+	// `RequestOptions.BypassCache`.
+	SetResponseCache(cache ResponseCache)
+
+	// SetRequestDeduplication enables or disables the deduplication of the
+	// identical search and read requests which are performed concurrently:
+	// only one of them reaches the Algolia servers and its response is then
+	// decoded for each of the callers. Requests are identical if they share
+	// the same method, path, body and headers. Disabled by default.
+	SetRequestDeduplication(enabled bool)
+
+	// SetValidationPolicy specifies how strictly the settings and the query
+	// parameters are validated before being sent: by default, only the types
+	// of the known keys are checked, while the WarnValidation and
+	// StrictValidation modes also report or reject the unknown keys, with a
+	// suggestion of the closest known key, and the values outside of their
+	// domain.
+	SetValidationPolicy(policy ValidationPolicy)
+
+	// SetSettingsSnapshotStore makes every `Index.SetSettings` call, and the
+	// methods relying on it, first save the current settings of the index
+	// into the given `store` so that they can later be restored with
+	// `Index.RollbackSettings`. If the snapshot cannot be saved, the settings
+	// are not changed. A nil `store` disables the snapshots, which is the
+	// default.
+	SetSettingsSnapshotStore(store SettingsSnapshotStore)
+
 	// ListIndexes returns the list of all indexes belonging to this Algolia
 	// application.
 	ListIndexes() (indexes []IndexRes, err error)
@@ -54,12 +95,24 @@ type Client interface {
 	// accepts extra RequestOptions.
 	ListIndexesWithRequestOptions(opts *RequestOptions) (indexes []IndexRes, err error)
 
+	// ListIndexesPage returns the page `page` of the list of the indexes
+	// belonging to this Algolia application, with `hitsPerPage` indexes per
+	// page. To iterate over all the indexes, use an IndexListIterator.
+	ListIndexesPage(page int, hitsPerPage int) (res ListIndexesRes, err error)
+
+	// ListIndexesPageWithRequestOptions is the same as ListIndexesPage but it
+	// also accepts extra RequestOptions.
+	ListIndexesPageWithRequestOptions(page int, hitsPerPage int, opts *RequestOptions) (res ListIndexesRes, err error)
+
 	// InitIndex returns an Index object targeting `name`.
 	InitIndex(name string) Index
 
 	// InitAnalytics returns a new Analytics instance, bound to the Client.
 	InitAnalytics() Analytics
 
+	// InitInsights returns a new Insights instance, bound to the Client.
+	InitInsights() Insights
+
 	// ListKeys returns all the API keys available for this Algolia
 	// application.
 	//
@@ -254,15 +307,27 @@ type Client interface {
 	BatchWithRequestOptions(operations []BatchOperationIndexed, opts *RequestOptions) (res MultipleBatchRes, err error)
 
 	// WaitTask stops the current execution until the task identified by its
-	// `taskID` on the index `indexName` is finished. The waiting time between each check is usually
-	// implemented by starting at 1s and increases by a factor of 2 at each
-	// retry (but is bounded at around 20min).
+	// `taskID` on the index `indexName` is finished. The waiting time between
+	// each check is controlled by the WaitPolicy of the client (see
+	// SetWaitPolicy). If the policy has a timeout and the task is still not
+	// published once it is reached, `WaitTaskTimeoutErr` is returned.
 	WaitTask(indexName string, taskID int) error
 
 	// WaitTaskWithRequestOptions is the same as WaitTask but it also accepts
-	// extra RequestOptions.
+	// extra RequestOptions. Their Context, if any, bounds the whole wait,
+	// including each status request.
 	WaitTaskWithRequestOptions(indexName string, taskID int, opts *RequestOptions) error
 
+	// WaitTasks is the same as WaitTask but waits concurrently for all the
+	// given `tasks`, which may belong to different indices. If any of them
+	// could not be waited for, a `*WaitTasksErr` is returned, holding the
+	// error of each failing task.
+	WaitTasks(tasks []IndexedTask) error
+
+	// WaitTasksWithRequestOptions is the same as WaitTasks but it also
+	// accepts extra RequestOptions.
+	WaitTasksWithRequestOptions(tasks []IndexedTask, opts *RequestOptions) error
+
 	// GetStatus returns the status of a task given its ID `taskID` and `indexName`.
 	GetStatus(indexName string, taskID int) (res TaskStatusRes, err error)
 
@@ -328,17 +393,86 @@ type Index interface {
 	// accepts extra RequestOptions.
 	GetSettingsWithRequestOptions(opts *RequestOptions) (settings Settings, err error)
 
-	// SetSettings changes the index settings.
+	// SetSettings changes the index settings. A nil value resets the setting
+	// to its default value. The untyped `settings` may also hold a
+	// `forwardToReplicas` bool, which is sent as a query parameter to apply
+	// the settings to the replicas of the index as well. This Map-based
+	// entry point is kept as-is for compatibility, see SetTypedSettings for
+	// the typed one.
 	SetSettings(settings Map) (res UpdateTaskRes, err error)
 
 	// SetSettingsWithRequestOptions is the same as SetSettings but it also
 	// accepts extra RequestOptions.
 	SetSettingsWithRequestOptions(settings Map, opts *RequestOptions) (res UpdateTaskRes, err error)
 
+	// SetTypedSettings is the same as SetSettings but it accepts typed
+	// Settings, whose nil fields are left untouched on the index. As the
+	// Settings returned by GetSettings are sent back without any loss, it can
+	// be used to copy the settings of an index, once they have been modified
+	// if needed.
+	//
+	// As Settings mirror what GetSettings returns, they have no room for the
+	// `forwardToReplicas` flag, which is not a setting but a parameter of the
+	// call: it is therefore an explicit argument here, as it is for
+	// ApplySettings (see ApplyOptions), instead of a key of the settings. Both
+	// methods end up sending the same request.
+	SetTypedSettings(settings Settings, forwardToReplicas bool) (res UpdateTaskRes, err error)
+
+	// SetTypedSettingsWithRequestOptions is the same as SetTypedSettings but
+	// it also accepts extra RequestOptions.
+	SetTypedSettingsWithRequestOptions(settings Settings, forwardToReplicas bool, opts *RequestOptions) (res UpdateTaskRes, err error)
+
+	// ApplySettings makes the settings of the index match the desired ones:
+	// only the non-nil fields of `desired` which differ from the current
+	// settings, as computed by DiffSettings, are sent. It then waits for the
+	// settings task to be published. With `options.DryRun`, the changes are
+	// only computed and nothing is sent.
+	ApplySettings(desired Settings, options ApplyOptions) (res ApplySettingsRes, err error)
+
+	// ApplySettingsWithRequestOptions is the same as ApplySettings but it
+	// also accepts extra RequestOptions.
+	ApplySettingsWithRequestOptions(desired Settings, options ApplyOptions, opts *RequestOptions) (res ApplySettingsRes, err error)
+
+	// SyncReplicas makes the replicas of the index match the given
+	// configuration: the declared replicas are attached to the index (which
+	// creates the missing ones), the other replicas are detached and possibly
+	// deleted, and the settings of each replica are updated from its sort
+	// criteria, the propagated settings of the index and its extra settings.
+	// It then waits for all the tasks to be published.
+	SyncReplicas(config ReplicasConfig) (res SyncReplicasRes, err error)
+
+	// SyncReplicasWithRequestOptions is the same as SyncReplicas but it also
+	// accepts extra RequestOptions.
+	SyncReplicasWithRequestOptions(config ReplicasConfig, opts *RequestOptions) (res SyncReplicasRes, err error)
+
+	// GetReplicasDrift reports how the replicas of the index differ from the
+	// given configuration, without changing anything. It is what
+	// SyncReplicas would fix, except that the settings of the missing
+	// replicas are not compared.
+	GetReplicasDrift(config ReplicasConfig) (drift ReplicasDrift, err error)
+
+	// GetReplicasDriftWithRequestOptions is the same as GetReplicasDrift but
+	// it also accepts extra RequestOptions.
+	GetReplicasDriftWithRequestOptions(config ReplicasConfig, opts *RequestOptions) (drift ReplicasDrift, err error)
+
+	// RollbackSettings restores the settings of the index saved in the
+	// SettingsSnapshot identified by `snapshotID`, as found in the
+	// SettingsSnapshotStore of the client (see
+	// Client.SetSettingsSnapshotStore). Only the settings which differ from
+	// the snapshot are sent: the ones which were not set when the snapshot
+	// was taken are reset to their default value. The replicas are never
+	// restored, as they may have been attached or detached since. The
+	// current settings are themselves saved in a new snapshot before being
+	// replaced.
+	RollbackSettings(snapshotID string) (res UpdateTaskRes, err error)
+
+	// RollbackSettingsWithRequestOptions is the same as RollbackSettings but
+	// it also accepts extra RequestOptions.
+	RollbackSettingsWithRequestOptions(snapshotID string, opts *RequestOptions) (res UpdateTaskRes, err error)
+
 	// WaitTask stops the current execution until the task identified by its
-	// `taskID` is finished. The waiting time between each check is usually
-	// implemented by starting at 1s and increases by a factor of 2 at each
-	// retry (but is bounded at around 20min).
+	// `taskID` is finished. The waiting time between each check is controlled
+	// by the WaitPolicy of the client (see Client.SetWaitPolicy).
 	WaitTask(taskID int) error
 
 	// WaitTaskWithRequestOptions is the same as WaitTask but it also accepts
@@ -640,6 +774,32 @@ type Index interface {
 	// extra RequestOptions.
 	BrowseAllWithRequestOptions(params Map, opts *RequestOptions) (it IndexIterator, err error)
 
+	// ResumeBrowseAll is the same as BrowseAll but the returned iterator
+	// starts from the given `checkpoint`, as previously returned by
+	// `IndexIterator.Checkpoint()`, instead of the first result. If
+	// `checkpointer` is non-nil, it is given a new checkpoint each time the
+	// iterator loads a new page, which lets a long iteration be resumed after
+	// a crash.
+	ResumeBrowseAll(params Map, checkpoint BrowseCheckpoint, checkpointer Checkpointer) (it IndexIterator, err error)
+
+	// ResumeBrowseAllWithRequestOptions is the same as ResumeBrowseAll but it
+	// also accepts extra RequestOptions.
+	ResumeBrowseAllWithRequestOptions(params Map, checkpoint BrowseCheckpoint, checkpointer Checkpointer, opts *RequestOptions) (it IndexIterator, err error)
+
+	// BrowseParallel browses concurrently the partitions of the index
+	// described by `options.Filters`, using at most `options.Workers`
+	// concurrent workers. The hits matching the given `params` are sent, in
+	// no particular order, to the returned `hits` channel, which is closed
+	// once all the partitions have been browsed, an error occurs or `ctx` is
+	// done. The `errs` channel then receives the first error which occurred,
+	// if any, before being closed as well. The `hits` channel must be drained
+	// until it is closed, unless `ctx` is cancelled.
+	BrowseParallel(ctx context.Context, params Map, options BrowseParallelOptions) (hits <-chan Map, errs <-chan error)
+
+	// BrowseParallelWithRequestOptions is the same as BrowseParallel but it
+	// also accepts extra RequestOptions.
+	BrowseParallelWithRequestOptions(ctx context.Context, params Map, options BrowseParallelOptions, opts *RequestOptions) (hits <-chan Map, errs <-chan error)
+
 	// Search performs a search query according to the `query` search query and
 	// the given `params`. More details here:
 	// https://www.algolia.com/doc/rest#query-an-index
@@ -674,6 +834,24 @@ type Index interface {
 	// Deprecated: Use DeleteByWithRequestOptions instead.
 	DeleteByQueryWithRequestOptions(query string, params Map, opts *RequestOptions) error
 
+	// DeleteByQueryInBatches finds all the records that match the `query`,
+	// according to the given `params`, and deletes them while browsing them.
+	// Deletions are sent in batches of at most `options.BatchSize` objectIDs,
+	// so that memory usage stays bounded whatever the number of matching
+	// records. Once all the batches have been sent, it hangs until they have
+	// all been processed.
+	//
+	// The `options.Progress` callback, if any, is called each time a batch of
+	// deletions has been sent, or after each browsed page during a dry run.
+	// If `options.DryRun` is set, the matching records are only counted, not
+	// deleted. If `ctx` gets cancelled, the deletion stops and the progress
+	// made so far is returned along with the context error.
+	DeleteByQueryInBatches(ctx context.Context, query string, params Map, options DeleteByQueryOptions) (res DeleteByQueryRes, err error)
+
+	// DeleteByQueryInBatchesWithRequestOptions is the same as
+	// DeleteByQueryInBatches but it also accepts extra RequestOptions.
+	DeleteByQueryInBatchesWithRequestOptions(ctx context.Context, query string, params Map, options DeleteByQueryOptions, opts *RequestOptions) (res DeleteByQueryRes, err error)
+
 	// SearchFacet searches inside a facet's values, optionally
 	// restricting the returned values to those contained in objects matching
 	// other (regular) search criteria. The `facet` parameter is the name of
@@ -686,6 +864,21 @@ type Index interface {
 	// Deprecated: Use SearchForFacetValues instead.
 	SearchFacet(facet, query string, params Map) (res SearchFacetRes, err error)
 
+	// DisjunctiveSearch performs a search query according to the `query`
+	// search query, the given `params` and the facets and refinements of the
+	// `state`, where the refinements of disjunctive facets are combined with
+	// an OR operator. The main query is sent along with one query per refined
+	// disjunctive facet in a single MultipleQueries call, and the results are
+	// merged so that the counts and stats of each disjunctive facet ignore
+	// its own refinements. The `facets`, `facetFilters` and `numericFilters`
+	// parameters are computed from the `state` and cannot be given in
+	// `params`.
+	DisjunctiveSearch(query string, params Map, state RefinementState) (res QueryRes, err error)
+
+	// DisjunctiveSearchWithRequestOptions is the same as DisjunctiveSearch
+	// but it also accepts extra RequestOptions.
+	DisjunctiveSearchWithRequestOptions(query string, params Map, state RefinementState, opts *RequestOptions) (res QueryRes, err error)
+
 	// SearchForFacetValues searches inside a facet's values, optionally
 	// restricting the returned values to those contained in objects matching
 	// other (regular) search criteria. The `facet` parameter is the name of
@@ -760,6 +953,28 @@ type Index interface {
 	// SearchRulesWithRequestOptions is the same as SearchRules but it also
 	// accepts extra RequestOptions.
 	SearchRulesWithRequestOptions(params Map, opts *RequestOptions) (SearchRulesRes, error)
+
+	// Export writes a backup of the index to `w`: its settings, Rules and
+	// synonyms followed by all of its records. The archive format is
+	// documented by IndexArchiveMetadata and can be restored with Import.
+	Export(w io.Writer) (res IndexArchiveRes, err error)
+
+	// ExportWithRequestOptions is the same as Export but it also accepts
+	// extra RequestOptions.
+	ExportWithRequestOptions(w io.Writer, opts *RequestOptions) (res IndexArchiveRes, err error)
+
+	// Import restores into the index an archive read from `r`, as produced by
+	// Export. Settings are applied first, then records are saved by batches
+	// and finally the existing Rules and synonyms are replaced by the ones of
+	// the archive. Records of the index which are not part of the archive are
+	// left untouched. The `replicas` setting of the archive is not restored,
+	// as the replicas belong to the exported index. It hangs until all the
+	// operations have completed.
+	Import(r io.Reader) (res IndexArchiveRes, err error)
+
+	// ImportWithRequestOptions is the same as Import but it also accepts
+	// extra RequestOptions.
+	ImportWithRequestOptions(r io.Reader, opts *RequestOptions) (res IndexArchiveRes, err error)
 }
 
 // IndexIterator is used by the BrowseAll functions to iterate over all the
@@ -771,32 +986,196 @@ type IndexIterator interface {
 	// occurs. When the last element is reached, an error is returned with the
 	// following message: "No more hits".
 	Next() (res Map, err error)
+
+	// Checkpoint returns the current position of the iterator: the browse
+	// cursor of the current page and the number of its hits already returned
+	// by `Next()`. It can be saved and given to `Index.ResumeBrowseAll` to
+	// resume the iteration later on.
+	Checkpoint() BrowseCheckpoint
 }
 
 type Analytics interface {
-	// AddABTest creates a new AB Test.
+	// AddABTest creates a new AB Test. Nothing is sent if the AB Test does not
+	// have exactly 2 variants whose traffic percentages add up to 100 or if it
+	// does not end in the future.
 	AddABTest(abTest ABTest) (res ABTestTaskRes, err error)
 
+	// AddABTestWithRequestOptions is the same as AddABTest but it also
+	// accepts extra RequestOptions.
+	AddABTestWithRequestOptions(abTest ABTest, opts *RequestOptions) (res ABTestTaskRes, err error)
+
 	// DeleteABTest stops the AB Test referenced by the given ID.
 	StopABTest(id int) (res ABTestTaskRes, err error)
 
+	// StopABTestWithRequestOptions is the same as StopABTest but it also
+	// accepts extra RequestOptions.
+	StopABTestWithRequestOptions(id int, opts *RequestOptions) (res ABTestTaskRes, err error)
+
 	// DeleteABTest removes the AB Test referenced by the given ID.
 	DeleteABTest(id int) (res ABTestTaskRes, err error)
 
+	// DeleteABTestWithRequestOptions is the same as DeleteABTest but it also
+	// accepts extra RequestOptions.
+	DeleteABTestWithRequestOptions(id int, opts *RequestOptions) (res ABTestTaskRes, err error)
+
 	// GetABTest returns the informations relative to the AB Test referenced by
 	// the given ID.
 	GetABTest(id int) (res ABTestResponse, err error)
 
+	// GetABTestWithRequestOptions is the same as GetABTest but it also
+	// accepts extra RequestOptions.
+	GetABTestWithRequestOptions(id int, opts *RequestOptions) (res ABTestResponse, err error)
+
 	// GetABTests retrieves a list of ABTests, according to the given
 	// parameters. The returned list may not be exhaustive, depending on the
 	// parameters that were provided.
 	//
 	// To retrieve the complete list of enabled AB tests, one should iterate
-	// over the multiple pages of result returned by GetABTests until no more
-	// AB Test is found.
-	GetABTests(params Map) (res GetABTestsRes, err error)
+	// over the multiple pages of result returned by GetABTests, increasing
+	// `params.Offset`, until no more AB Test is found.
+	GetABTests(params GetABTestsParams) (res GetABTestsRes, err error)
+
+	// GetABTestsWithRequestOptions is the same as GetABTests but it also
+	// accepts extra RequestOptions.
+	GetABTestsWithRequestOptions(params GetABTestsParams, opts *RequestOptions) (res GetABTestsRes, err error)
 
 	// WaitTask blocks until the given task has ended successfully. If anything
 	// goes wrong or if the task did not succeed, a non-nil error is returned.
 	WaitTask(task ABTestTaskRes) (err error)
+
+	// GetTopSearches returns the most frequent searches of the index, along
+	// with their number of hits and, if `params.ClickAnalytics` is set, their
+	// click and conversion metrics.
+	GetTopSearches(params AnalyticsParams) (res TopSearchesRes, err error)
+
+	// GetTopSearchesWithRequestOptions is the same as GetTopSearches but it
+	// also accepts extra RequestOptions.
+	GetTopSearchesWithRequestOptions(params AnalyticsParams, opts *RequestOptions) (res TopSearchesRes, err error)
+
+	// GetSearchesCount returns the total number of searches of the index, as
+	// well as the number of searches of each day.
+	GetSearchesCount(params AnalyticsParams) (res CountRes, err error)
+
+	// GetSearchesCountWithRequestOptions is the same as GetSearchesCount but
+	// it also accepts extra RequestOptions.
+	GetSearchesCountWithRequestOptions(params AnalyticsParams, opts *RequestOptions) (res CountRes, err error)
+
+	// GetSearchesNoResults returns the most frequent searches of the index
+	// which did not return any result.
+	GetSearchesNoResults(params AnalyticsParams) (res NoResultsSearchesRes, err error)
+
+	// GetSearchesNoResultsWithRequestOptions is the same as
+	// GetSearchesNoResults but it also accepts extra RequestOptions.
+	GetSearchesNoResultsWithRequestOptions(params AnalyticsParams, opts *RequestOptions) (res NoResultsSearchesRes, err error)
+
+	// GetNoResultsRate returns the rate of the searches of the index which did
+	// not return any result, overall and for each day.
+	GetNoResultsRate(params AnalyticsParams) (res NoResultsRateRes, err error)
+
+	// GetNoResultsRateWithRequestOptions is the same as GetNoResultsRate but
+	// it also accepts extra RequestOptions.
+	GetNoResultsRateWithRequestOptions(params AnalyticsParams, opts *RequestOptions) (res NoResultsRateRes, err error)
+
+	// GetTopHits returns the objectIDs of the most frequent hits of the index.
+	// If `search` is non-empty, only the hits of this search are considered.
+	GetTopHits(search string, params AnalyticsParams) (res TopHitsRes, err error)
+
+	// GetTopHitsWithRequestOptions is the same as GetTopHits but it also
+	// accepts extra RequestOptions.
+	GetTopHitsWithRequestOptions(search string, params AnalyticsParams, opts *RequestOptions) (res TopHitsRes, err error)
+
+	// GetTopFilterAttributes returns the attributes most frequently used to
+	// filter the searches of the index. If `search` is non-empty, only the
+	// filters of this search are considered.
+	GetTopFilterAttributes(search string, params AnalyticsParams) (res TopFilterAttributesRes, err error)
+
+	// GetTopFilterAttributesWithRequestOptions is the same as
+	// GetTopFilterAttributes but it also accepts extra RequestOptions.
+	GetTopFilterAttributesWithRequestOptions(search string, params AnalyticsParams, opts *RequestOptions) (res TopFilterAttributesRes, err error)
+
+	// GetTopFilterValues returns the values most frequently used to filter the
+	// searches of the index on the given attribute. If `search` is non-empty,
+	// only the filters of this search are considered.
+	GetTopFilterValues(attribute, search string, params AnalyticsParams) (res TopFilterValuesRes, err error)
+
+	// GetTopFilterValuesWithRequestOptions is the same as GetTopFilterValues
+	// but it also accepts extra RequestOptions.
+	GetTopFilterValuesWithRequestOptions(attribute, search string, params AnalyticsParams, opts *RequestOptions) (res TopFilterValuesRes, err error)
+
+	// GetClickThroughRate returns the rate of the tracked searches of the
+	// index which led to at least one click, overall and for each day.
+	GetClickThroughRate(params AnalyticsParams) (res ClickThroughRateRes, err error)
+
+	// GetClickThroughRateWithRequestOptions is the same as
+	// GetClickThroughRate but it also accepts extra RequestOptions.
+	GetClickThroughRateWithRequestOptions(params AnalyticsParams, opts *RequestOptions) (res ClickThroughRateRes, err error)
+
+	// GetConversionRate returns the rate of the tracked searches of the index
+	// which led to at least one conversion, overall and for each day.
+	GetConversionRate(params AnalyticsParams) (res ConversionRateRes, err error)
+
+	// GetConversionRateWithRequestOptions is the same as GetConversionRate but
+	// it also accepts extra RequestOptions.
+	GetConversionRateWithRequestOptions(params AnalyticsParams, opts *RequestOptions) (res ConversionRateRes, err error)
+
+	// GetUsersCount returns the number of distinct users who searched the
+	// index, overall and for each day.
+	GetUsersCount(params AnalyticsParams) (res CountRes, err error)
+
+	// GetUsersCountWithRequestOptions is the same as GetUsersCount but it also
+	// accepts extra RequestOptions.
+	GetUsersCountWithRequestOptions(params AnalyticsParams, opts *RequestOptions) (res CountRes, err error)
+}
+
+type Insights interface {
+	// SendEvent sends a single event to the Algolia Insights API.
+	SendEvent(event InsightsEvent) (res InsightsRes, err error)
+
+	// SendEvents sends the given events to the Algolia Insights API, by
+	// batches of at most 1000 events. The events are all validated before
+	// anything is sent. To buffer events and send them later on, use an
+	// InsightsBuffer.
+	SendEvents(events []InsightsEvent) (res InsightsRes, err error)
+
+	// SendEventsWithRequestOptions is the same as SendEvents but it also
+	// accepts extra RequestOptions.
+	SendEventsWithRequestOptions(events []InsightsEvent, opts *RequestOptions) (res InsightsRes, err error)
+
+	// User returns a UserInsights sending the events of the user identified
+	// by `userToken`.
+	User(userToken string) UserInsights
+}
+
+// UserInsights sends the events of a single user to the Algolia Insights API.
+// The events related to a search should use the `QueryRes.QueryID` of this
+// search, which is only returned if the `clickAnalytics` parameter was
+// enabled. Positions are the 1-based positions of the clicked records in the
+// search results.
+type UserInsights interface {
+	// ClickedObjectIDs sends a click event on the given records.
+	ClickedObjectIDs(eventName, indexName string, objectIDs []string) (res InsightsRes, err error)
+
+	// ClickedObjectIDsAfterSearch sends a click event on the given records,
+	// found at the given positions of the results of the search identified
+	// by `queryID`.
+	ClickedObjectIDsAfterSearch(eventName, indexName string, objectIDs []string, positions []int, queryID string) (res InsightsRes, err error)
+
+	// ClickedFilters sends a click event on the given filters.
+	ClickedFilters(eventName, indexName string, filters []string) (res InsightsRes, err error)
+
+	// ConvertedObjectIDs sends a conversion event on the given records.
+	ConvertedObjectIDs(eventName, indexName string, objectIDs []string) (res InsightsRes, err error)
+
+	// ConvertedObjectIDsAfterSearch sends a conversion event on the given
+	// records, found by the search identified by `queryID`.
+	ConvertedObjectIDsAfterSearch(eventName, indexName string, objectIDs []string, queryID string) (res InsightsRes, err error)
+
+	// ConvertedFilters sends a conversion event on the given filters.
+	ConvertedFilters(eventName, indexName string, filters []string) (res InsightsRes, err error)
+
+	// ViewedObjectIDs sends a view event on the given records.
+	ViewedObjectIDs(eventName, indexName string, objectIDs []string) (res InsightsRes, err error)
+
+	// ViewedFilters sends a view event on the given filters.
+	ViewedFilters(eventName, indexName string, filters []string) (res InsightsRes, err error)
 }
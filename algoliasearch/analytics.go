@@ -1,6 +1,9 @@
 package algoliasearch
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 type analytics struct {
 	client         *client
@@ -15,32 +18,61 @@ func NewAnalytics(client *client) *analytics {
 }
 
 func (a *analytics) AddABTest(abTest ABTest) (res ABTestTaskRes, err error) {
+	return a.AddABTestWithRequestOptions(abTest, nil)
+}
+
+func (a *analytics) AddABTestWithRequestOptions(abTest ABTest, opts *RequestOptions) (res ABTestTaskRes, err error) {
+	if err = checkABTest(abTest, time.Now(), a.client.checkQuery); err != nil {
+		return
+	}
 	path := a.abTestingRoute
-	err = a.client.request(&res, "POST", path, abTest, analyticsCall, nil)
+	err = a.client.request(&res, "POST", path, abTest, analyticsCall, opts)
+	res.bind(a.client, res.Index, opts)
 	return
 }
 
 func (a *analytics) StopABTest(id int) (res ABTestTaskRes, err error) {
+	return a.StopABTestWithRequestOptions(id, nil)
+}
+
+func (a *analytics) StopABTestWithRequestOptions(id int, opts *RequestOptions) (res ABTestTaskRes, err error) {
 	path := fmt.Sprintf("%s/%d/stop", a.abTestingRoute, id)
-	err = a.client.request(&res, "POST", path, nil, analyticsCall, nil)
+	err = a.client.request(&res, "POST", path, nil, analyticsCall, opts)
+	res.bind(a.client, res.Index, opts)
 	return
 }
 
 func (a *analytics) DeleteABTest(id int) (res ABTestTaskRes, err error) {
+	return a.DeleteABTestWithRequestOptions(id, nil)
+}
+
+func (a *analytics) DeleteABTestWithRequestOptions(id int, opts *RequestOptions) (res ABTestTaskRes, err error) {
 	path := fmt.Sprintf("%s/%d", a.abTestingRoute, id)
-	err = a.client.request(&res, "DELETE", path, nil, analyticsCall, nil)
+	err = a.client.request(&res, "DELETE", path, nil, analyticsCall, opts)
+	res.bind(a.client, res.Index, opts)
 	return
 }
 
 func (a *analytics) GetABTest(id int) (res ABTestResponse, err error) {
+	return a.GetABTestWithRequestOptions(id, nil)
+}
+
+func (a *analytics) GetABTestWithRequestOptions(id int, opts *RequestOptions) (res ABTestResponse, err error) {
 	path := fmt.Sprintf("%s/%d", a.abTestingRoute, id)
-	err = a.client.request(&res, "GET", path, nil, analyticsCall, nil)
+	err = a.client.request(&res, "GET", path, nil, analyticsCall, opts)
 	return
 }
 
-func (a *analytics) GetABTests(params Map) (res GetABTestsRes, err error) {
+func (a *analytics) GetABTests(params GetABTestsParams) (res GetABTestsRes, err error) {
+	return a.GetABTestsWithRequestOptions(params, nil)
+}
+
+func (a *analytics) GetABTestsWithRequestOptions(params GetABTestsParams, opts *RequestOptions) (res GetABTestsRes, err error) {
+	if err = checkGetABTestsParams(params); err != nil {
+		return
+	}
 	path := a.abTestingRoute
-	err = a.client.request(&res, "GET", path, params, analyticsCall, nil)
+	err = a.client.request(&res, "GET", path, params.toMap(), analyticsCall, opts)
 	return
 }
 
@@ -0,0 +1,145 @@
+package algoliasearch
+
+import "net/url"
+
+// searchAnalytics performs a GET request to the given path of the Analytics
+// API with the given parameters.
+func (a *analytics) searchAnalytics(res interface{}, path string, params Map, opts *RequestOptions) error {
+	return a.client.request(res, "GET", path, params, analyticsCall, opts)
+}
+
+func (a *analytics) GetTopSearches(params AnalyticsParams) (res TopSearchesRes, err error) {
+	return a.GetTopSearchesWithRequestOptions(params, nil)
+}
+
+func (a *analytics) GetTopSearchesWithRequestOptions(params AnalyticsParams, opts *RequestOptions) (res TopSearchesRes, err error) {
+	if err = checkAnalyticsParams(params); err != nil {
+		return
+	}
+	err = a.searchAnalytics(&res, "/2/searches", params.toMap(), opts)
+	return
+}
+
+func (a *analytics) GetSearchesCount(params AnalyticsParams) (res CountRes, err error) {
+	return a.GetSearchesCountWithRequestOptions(params, nil)
+}
+
+func (a *analytics) GetSearchesCountWithRequestOptions(params AnalyticsParams, opts *RequestOptions) (res CountRes, err error) {
+	if err = checkAnalyticsParams(params); err != nil {
+		return
+	}
+	err = a.searchAnalytics(&res, "/2/searches/count", params.toMap(), opts)
+	return
+}
+
+func (a *analytics) GetSearchesNoResults(params AnalyticsParams) (res NoResultsSearchesRes, err error) {
+	return a.GetSearchesNoResultsWithRequestOptions(params, nil)
+}
+
+func (a *analytics) GetSearchesNoResultsWithRequestOptions(params AnalyticsParams, opts *RequestOptions) (res NoResultsSearchesRes, err error) {
+	if err = checkAnalyticsParams(params); err != nil {
+		return
+	}
+	err = a.searchAnalytics(&res, "/2/searches/noResults", params.toMap(), opts)
+	return
+}
+
+func (a *analytics) GetNoResultsRate(params AnalyticsParams) (res NoResultsRateRes, err error) {
+	return a.GetNoResultsRateWithRequestOptions(params, nil)
+}
+
+func (a *analytics) GetNoResultsRateWithRequestOptions(params AnalyticsParams, opts *RequestOptions) (res NoResultsRateRes, err error) {
+	if err = checkAnalyticsParams(params); err != nil {
+		return
+	}
+	err = a.searchAnalytics(&res, "/2/searches/noResultRate", params.toMap(), opts)
+	return
+}
+
+func (a *analytics) GetTopHits(search string, params AnalyticsParams) (res TopHitsRes, err error) {
+	return a.GetTopHitsWithRequestOptions(search, params, nil)
+}
+
+func (a *analytics) GetTopHitsWithRequestOptions(search string, params AnalyticsParams, opts *RequestOptions) (res TopHitsRes, err error) {
+	if err = checkAnalyticsParams(params); err != nil {
+		return
+	}
+	m := params.toMap()
+	if search != "" {
+		m["search"] = search
+	}
+	err = a.searchAnalytics(&res, "/2/hits", m, opts)
+	return
+}
+
+func (a *analytics) GetTopFilterAttributes(search string, params AnalyticsParams) (res TopFilterAttributesRes, err error) {
+	return a.GetTopFilterAttributesWithRequestOptions(search, params, nil)
+}
+
+func (a *analytics) GetTopFilterAttributesWithRequestOptions(search string, params AnalyticsParams, opts *RequestOptions) (res TopFilterAttributesRes, err error) {
+	if err = checkAnalyticsParams(params); err != nil {
+		return
+	}
+	m := params.toMap()
+	if search != "" {
+		m["search"] = search
+	}
+	err = a.searchAnalytics(&res, "/2/filters", m, opts)
+	return
+}
+
+func (a *analytics) GetTopFilterValues(attribute, search string, params AnalyticsParams) (res TopFilterValuesRes, err error) {
+	return a.GetTopFilterValuesWithRequestOptions(attribute, search, params, nil)
+}
+
+func (a *analytics) GetTopFilterValuesWithRequestOptions(attribute, search string, params AnalyticsParams, opts *RequestOptions) (res TopFilterValuesRes, err error) {
+	if attribute == "" {
+		err = emptyField("attribute")
+		return
+	}
+	if err = checkAnalyticsParams(params); err != nil {
+		return
+	}
+	m := params.toMap()
+	if search != "" {
+		m["search"] = search
+	}
+	err = a.searchAnalytics(&res, "/2/filters/"+url.QueryEscape(attribute), m, opts)
+	return
+}
+
+func (a *analytics) GetClickThroughRate(params AnalyticsParams) (res ClickThroughRateRes, err error) {
+	return a.GetClickThroughRateWithRequestOptions(params, nil)
+}
+
+func (a *analytics) GetClickThroughRateWithRequestOptions(params AnalyticsParams, opts *RequestOptions) (res ClickThroughRateRes, err error) {
+	if err = checkAnalyticsParams(params); err != nil {
+		return
+	}
+	err = a.searchAnalytics(&res, "/2/clicks/clickThroughRate", params.toMap(), opts)
+	return
+}
+
+func (a *analytics) GetConversionRate(params AnalyticsParams) (res ConversionRateRes, err error) {
+	return a.GetConversionRateWithRequestOptions(params, nil)
+}
+
+func (a *analytics) GetConversionRateWithRequestOptions(params AnalyticsParams, opts *RequestOptions) (res ConversionRateRes, err error) {
+	if err = checkAnalyticsParams(params); err != nil {
+		return
+	}
+	err = a.searchAnalytics(&res, "/2/conversions/conversionRate", params.toMap(), opts)
+	return
+}
+
+func (a *analytics) GetUsersCount(params AnalyticsParams) (res CountRes, err error) {
+	return a.GetUsersCountWithRequestOptions(params, nil)
+}
+
+func (a *analytics) GetUsersCountWithRequestOptions(params AnalyticsParams, opts *RequestOptions) (res CountRes, err error) {
+	if err = checkAnalyticsParams(params); err != nil {
+		return
+	}
+	err = a.searchAnalytics(&res, "/2/users/count", params.toMap(), opts)
+	return
+}
@@ -0,0 +1,148 @@
+package algoliasearch
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyticsParams_toMap(t *testing.T) {
+	require.Equal(t, Map{"index": "products"}, AnalyticsParams{Index: "products"}.toMap())
+
+	params := AnalyticsParams{
+		Index:          "products",
+		StartDate:      time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC),
+		EndDate:        time.Date(2018, 6, 8, 0, 0, 0, 0, time.UTC),
+		Tags:           "platform:mobile",
+		Limit:          20,
+		Offset:         40,
+		ClickAnalytics: true,
+	}
+	require.Equal(t, Map{
+		"index":          "products",
+		"startDate":      "2018-06-01",
+		"endDate":        "2018-06-08",
+		"tags":           "platform:mobile",
+		"limit":          20,
+		"offset":         40,
+		"clickAnalytics": true,
+	}, params.toMap())
+}
+
+func TestCheckAnalyticsParams(t *testing.T) {
+	now := time.Now()
+
+	for _, c := range []struct {
+		params  AnalyticsParams
+		isValid bool
+	}{
+		{AnalyticsParams{Index: "products"}, true},
+		{AnalyticsParams{Index: "products", StartDate: now, EndDate: now}, true},
+		{AnalyticsParams{Index: "products", StartDate: now}, true},
+		{AnalyticsParams{}, false},
+		{AnalyticsParams{Index: "products", StartDate: now, EndDate: now.AddDate(0, 0, -1)}, false},
+		{AnalyticsParams{Index: "products", Limit: -1}, false},
+		{AnalyticsParams{Index: "products", Offset: -1}, false},
+	} {
+		err := checkAnalyticsParams(c.params)
+		if c.isValid {
+			require.NoError(t, err, "%#v", c.params)
+		} else {
+			require.Error(t, err, "%#v", c.params)
+		}
+	}
+}
+
+func TestAnalytics_GetTopSearches(t *testing.T) {
+	rt := &recordingRoundTripper{
+		response: `{"searches":[{"search":"phone","count":42,"nbHits":7,"clickThroughRate":0.5,"clickCount":10,"trackedSearchCount":20}]}`,
+	}
+	c := NewClient("appID", "apiKey")
+	c.SetHTTPClient(&http.Client{Transport: rt})
+
+	res, err := c.InitAnalytics().GetTopSearches(AnalyticsParams{
+		Index:          "products",
+		StartDate:      time.Date(2018, 6, 1, 0, 0, 0, 0, time.UTC),
+		Tags:           "platform:mobile",
+		Limit:          5,
+		ClickAnalytics: true,
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Searches, 1)
+	require.Equal(t, "phone", res.Searches[0].Search)
+	require.Equal(t, 42, res.Searches[0].Count)
+	require.Equal(t, 7, res.Searches[0].NbHits)
+	require.Equal(t, 0.5, res.Searches[0].ClickThroughRate)
+	require.Equal(t, 20, res.Searches[0].TrackedSearchCount)
+
+	require.Len(t, rt.requests, 1)
+	req := rt.requests[0]
+	require.Equal(t, "GET", req.Method)
+	require.Equal(t, "analytics.algolia.com", req.URL.Host)
+	require.Equal(t, "/2/searches", req.URL.Path)
+
+	query := req.URL.Query()
+	require.Equal(t, "products", query.Get("index"))
+	require.Equal(t, "2018-06-01", query.Get("startDate"))
+	require.Equal(t, "", query.Get("endDate"))
+	require.Equal(t, "platform:mobile", query.Get("tags"))
+	require.Equal(t, "5", query.Get("limit"))
+	require.Equal(t, "true", query.Get("clickAnalytics"))
+}
+
+func TestAnalytics_paths(t *testing.T) {
+	rt := &recordingRoundTripper{response: `{}`}
+	c := NewClient("appID", "apiKey")
+	c.SetHTTPClient(&http.Client{Transport: rt})
+	a := c.InitAnalytics()
+	params := AnalyticsParams{Index: "products"}
+
+	for _, c := range []struct {
+		expectedPath string
+		call         func() error
+	}{
+		{"/2/searches/count", func() (err error) { _, err = a.GetSearchesCount(params); return }},
+		{"/2/searches/noResults", func() (err error) { _, err = a.GetSearchesNoResults(params); return }},
+		{"/2/searches/noResultRate", func() (err error) { _, err = a.GetNoResultsRate(params); return }},
+		{"/2/hits", func() (err error) { _, err = a.GetTopHits("phone", params); return }},
+		{"/2/filters", func() (err error) { _, err = a.GetTopFilterAttributes("", params); return }},
+		{"/2/filters/brand", func() (err error) { _, err = a.GetTopFilterValues("brand", "", params); return }},
+		{"/2/clicks/clickThroughRate", func() (err error) { _, err = a.GetClickThroughRate(params); return }},
+		{"/2/conversions/conversionRate", func() (err error) { _, err = a.GetConversionRate(params); return }},
+		{"/2/users/count", func() (err error) { _, err = a.GetUsersCount(params); return }},
+	} {
+		rt.requests = nil
+		require.NoError(t, c.call(), c.expectedPath)
+		require.Len(t, rt.requests, 1, c.expectedPath)
+		require.Equal(t, c.expectedPath, rt.requests[0].URL.Path)
+		require.Equal(t, "products", rt.requests[0].URL.Query().Get("index"))
+	}
+
+	_, err := a.GetTopFilterValues("", "", params)
+	require.Error(t, err)
+
+	_, err = a.GetTopSearches(AnalyticsParams{})
+	require.Error(t, err)
+	require.Len(t, rt.requests, 1, "invalid parameters should not be sent")
+}
+
+func TestAnalytics_GetClickThroughRate(t *testing.T) {
+	rt := &recordingRoundTripper{
+		response: `{"rate":0.25,"clickCount":5,"trackedSearchCount":20,"dates":[{"date":"2018-06-01","rate":0.25,"clickCount":5,"trackedSearchCount":20}]}`,
+	}
+	c := NewClient("appID", "apiKey")
+	c.SetHTTPClient(&http.Client{Transport: rt})
+
+	res, err := c.InitAnalytics().GetClickThroughRate(AnalyticsParams{Index: "products"})
+	require.NoError(t, err)
+	require.Equal(t, ClickThroughRateRes{
+		Rate:               0.25,
+		ClickCount:         5,
+		TrackedSearchCount: 20,
+		Dates: []DailyClickThroughRate{
+			{Date: "2018-06-01", Rate: 0.25, ClickCount: 5, TrackedSearchCount: 20},
+		},
+	}, res)
+}
@@ -1,7 +1,9 @@
 package algoliasearch
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"testing"
 	"time"
 
@@ -14,7 +16,7 @@ func TestABTesting(t *testing.T) {
 
 	t.Log("TestABTesting: Remove any pre-existing AB test")
 	for {
-		res, err := a.GetABTests(nil)
+		res, err := a.GetABTests(GetABTestsParams{})
 		require.NoError(t, err)
 		if res.Count == 0 || res.Total == 0 {
 			break
@@ -69,9 +71,9 @@ func TestABTesting(t *testing.T) {
 
 	t.Log("TestABTesting: Retrieve added AB test from all AB tests")
 	{
-		res, err := a.GetABTests(Map{
-			"offset": 0,
-			"limit":  10,
+		res, err := a.GetABTests(GetABTestsParams{
+			Limit:       10,
+			IndexPrefix: indexNamePrefix,
 		})
 		require.NoError(t, err)
 		require.Equal(t, 1, res.Count)
@@ -131,3 +133,159 @@ func compareVariants(t *testing.T, expected []Variant, got []VariantResponse) {
 
 	require.Equal(t, len(expected), found)
 }
+
+func TestAnalytics_GetABTestsWithRequestOptions(t *testing.T) {
+	rt := &recordingRoundTripper{response: `{"abtests":null,"count":0,"total":0}`}
+	c := NewClient("appID", "apiKey")
+	c.SetHTTPClient(&http.Client{Transport: rt})
+
+	_, err := c.InitAnalytics().GetABTestsWithRequestOptions(
+		GetABTestsParams{Offset: 20, Limit: 10, IndexPrefix: "prod_"},
+		&RequestOptions{ExtraHeaders: map[string]string{"X-Custom": "value"}},
+	)
+	require.NoError(t, err)
+
+	require.Len(t, rt.requests, 1)
+	req := rt.requests[0]
+	require.Equal(t, "analytics.algolia.com", req.URL.Host)
+	require.Equal(t, "/2/abtests", req.URL.Path)
+	require.Equal(t, "value", req.Header.Get("X-Custom"))
+
+	query := req.URL.Query()
+	require.Equal(t, "20", query.Get("offset"))
+	require.Equal(t, "10", query.Get("limit"))
+	require.Equal(t, "prod_", query.Get("indexPrefix"))
+	require.Equal(t, "", query.Get("indexSuffix"))
+
+	_, err = c.InitAnalytics().GetABTests(GetABTestsParams{Limit: -1})
+	require.Error(t, err)
+	require.Len(t, rt.requests, 1)
+}
+
+func TestAnalytics_AddABTest_strictValidation(t *testing.T) {
+	rt := &recordingRoundTripper{response: `{"abTestID": 1, "taskID": 2, "index": "products"}`}
+	c := NewClient("appID", "apiKey")
+	c.SetHTTPClient(&http.Client{Transport: rt})
+	c.SetValidationPolicy(ValidationPolicy{Mode: StrictValidation})
+
+	_, err := c.InitAnalytics().AddABTest(ABTest{
+		Name: "rules",
+		Variants: []Variant{
+			{Index: "products", TrafficPercentage: 50},
+			{Index: "products", TrafficPercentage: 50, CustomSearchParameters: Map{"enableRule": false}},
+		},
+		EndAt: time.Now().Add(time.Hour),
+	})
+	require.EqualError(t, err, "ABTest.Variants[1].CustomSearchParameters: unknown query parameter `enableRule`: did you mean `enableRules`?")
+	require.Empty(t, rt.requests)
+}
+
+func TestABTest_MarshalJSON(t *testing.T) {
+	abTest := ABTest{
+		Name: `the "best" test`,
+		Variants: []Variant{
+			{Index: "products", TrafficPercentage: 60},
+			{Index: "products_new", TrafficPercentage: 40, Description: "new ranking"},
+		},
+		EndAt: time.Date(2018, 6, 1, 14, 0, 0, 0, time.FixedZone("UTC+2", 2*3600)),
+	}
+
+	data, err := json.Marshal(abTest)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"name": "the \"best\" test",
+		"variants": [
+			{"index": "products", "trafficPercentage": 60},
+			{"index": "products_new", "trafficPercentage": 40, "description": "new ranking"}
+		],
+		"endAt": "2018-06-01T12:00:00Z"
+	}`, string(data))
+}
+
+func TestCheckABTest(t *testing.T) {
+	now := time.Now()
+	variants := func(percentages ...int) (variants []Variant) {
+		for i, percentage := range percentages {
+			variants = append(variants, Variant{Index: fmt.Sprintf("index%d", i), TrafficPercentage: percentage})
+		}
+		return
+	}
+
+	for _, c := range []struct {
+		abTest  ABTest
+		isValid bool
+	}{
+		{ABTest{Name: "test", Variants: variants(60, 40), EndAt: now.Add(time.Hour)}, true},
+		{ABTest{Name: "", Variants: variants(60, 40), EndAt: now.Add(time.Hour)}, false},
+		{ABTest{Name: "test", Variants: variants(100), EndAt: now.Add(time.Hour)}, false},
+		{ABTest{Name: "test", Variants: variants(50, 30, 20), EndAt: now.Add(time.Hour)}, false},
+		{ABTest{Name: "test", Variants: variants(60, 50), EndAt: now.Add(time.Hour)}, false},
+		{ABTest{Name: "test", Variants: variants(100, 0), EndAt: now.Add(time.Hour)}, false},
+		{ABTest{Name: "test", Variants: []Variant{{TrafficPercentage: 50}, {Index: "index", TrafficPercentage: 50}}, EndAt: now.Add(time.Hour)}, false},
+		{ABTest{Name: "test", Variants: variants(60, 40), EndAt: now}, false},
+		{ABTest{Name: "test", Variants: variants(60, 40)}, false},
+	} {
+		err := checkABTest(c.abTest, now, (&client{}).checkQuery)
+		if c.isValid {
+			require.NoError(t, err, "%#v", c.abTest)
+		} else {
+			require.Error(t, err, "%#v", c.abTest)
+		}
+	}
+}
+
+func TestVariant_customSearchParameters(t *testing.T) {
+	now := time.Now()
+	abTest := ABTest{
+		Name: "rules",
+		Variants: []Variant{
+			{Index: "products", TrafficPercentage: 50},
+			{Index: "products", TrafficPercentage: 50, CustomSearchParameters: Map{
+				"enableRules":  false,
+				"aroundLatLng": GeoPoint{Lat: 48.85, Lng: 2.35},
+			}},
+		},
+		EndAt: now.Add(time.Hour),
+	}
+
+	t.Log("TestVariant_customSearchParameters: Encode typed search parameters like for search")
+	{
+		data, err := json.Marshal(abTest.Variants[1])
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+			"index": "products",
+			"trafficPercentage": 50,
+			"customSearchParameters": {"enableRules": false, "aroundLatLng": "48.85,2.35"}
+		}`, string(data))
+
+		data, err = json.Marshal(abTest.Variants[0])
+		require.NoError(t, err)
+		require.JSONEq(t, `{"index": "products", "trafficPercentage": 50}`, string(data))
+	}
+
+	t.Log("TestVariant_customSearchParameters: Validate custom search parameters")
+	{
+		require.NoError(t, checkABTest(abTest, now, (&client{}).checkQuery))
+
+		invalid := abTest
+		invalid.Variants = []Variant{abTest.Variants[0], abTest.Variants[1]}
+		invalid.Variants[1].Index = "products_new"
+		require.Error(t, checkABTest(invalid, now, (&client{}).checkQuery))
+
+		invalid.Variants = []Variant{abTest.Variants[1], abTest.Variants[0]}
+		require.Error(t, checkABTest(invalid, now, (&client{}).checkQuery))
+
+		invalid.Variants = []Variant{abTest.Variants[0], abTest.Variants[1]}
+		invalid.Variants[1].CustomSearchParameters = Map{"aroundLatLng": GeoPoint{Lat: 100}}
+		require.Error(t, checkABTest(invalid, now, (&client{}).checkQuery))
+	}
+
+	t.Log("TestVariant_customSearchParameters: Decode custom search parameters of AB Tests")
+	{
+		var res ABTestResponse
+		err := json.Unmarshal([]byte(`{"variants":[{"index":"products"},{"index":"products","customSearchParameters":{"enableRules":false}}]}`), &res)
+		require.NoError(t, err)
+		require.Nil(t, res.Variants[0].CustomSearchParameters)
+		require.Equal(t, Map{"enableRules": false}, res.Variants[1].CustomSearchParameters)
+	}
+}
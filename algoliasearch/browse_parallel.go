@@ -0,0 +1,148 @@
+package algoliasearch
+
+import (
+	"context"
+	"sync"
+)
+
+// defaultBrowseParallelWorkers is the number of partitions browsed
+// concurrently by `BrowseParallel` when no worker count is given.
+const defaultBrowseParallelWorkers = 4
+
+func (i *index) BrowseParallel(ctx context.Context, params Map, options BrowseParallelOptions) (hits <-chan Map, errs <-chan error) {
+	return i.BrowseParallelWithRequestOptions(ctx, params, options, nil)
+}
+
+func (i *index) BrowseParallelWithRequestOptions(ctx context.Context, params Map, options BrowseParallelOptions, opts *RequestOptions) (hits <-chan Map, errs <-chan error) {
+	if err := i.client.checkQuery(params); err != nil {
+		return failedBrowseParallel(err)
+	}
+	return browseParallel(ctx, i, params, options, opts)
+}
+
+// failedBrowseParallel returns the channels of a BrowseParallel call which
+// failed before browsing anything: no hit and only the given error.
+func failedBrowseParallel(err error) (<-chan Map, <-chan error) {
+	hits := make(chan Map)
+	errs := make(chan error, 1)
+	close(hits)
+	errs <- err
+	close(errs)
+	return hits, errs
+}
+
+// browseParallel implements `BrowseParallel` on top of any Index so that it
+// only relies on its `BrowseWithRequestOptions` method. The `params` are
+// expected to have already been checked.
+func browseParallel(ctx context.Context, i Index, params Map, options BrowseParallelOptions, opts *RequestOptions) (<-chan Map, <-chan error) {
+	hits := make(chan Map)
+	errs := make(chan error, 1)
+
+	partitions := partitionParams(params, options.Filters)
+
+	workers := options.Workers
+	if workers <= 0 {
+		workers = defaultBrowseParallelWorkers
+	}
+	if workers > len(partitions) {
+		workers = len(partitions)
+	}
+
+	// The first error cancels the browsing of all the other partitions.
+	ctx, cancel := context.WithCancel(ctx)
+
+	var once sync.Once
+	var firstErr error
+	fail := func(err error) {
+		once.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	queue := make(chan Map, len(partitions))
+	for _, partition := range partitions {
+		queue <- partition
+	}
+	close(queue)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for partition := range queue {
+				if err := browsePartition(ctx, i, partition, hits, opts); err != nil {
+					fail(err)
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		cancel()
+		close(hits)
+		if firstErr != nil {
+			errs <- firstErr
+		}
+		close(errs)
+	}()
+
+	return hits, errs
+}
+
+// partitionParams returns the browse parameters of each partition, combining
+// the `filters` parameter with each of the partition filters.
+func partitionParams(params Map, filters []string) (partitions []Map) {
+	if len(filters) == 0 {
+		return []Map{duplicateMap(params)}
+	}
+
+	initial, _ := params["filters"].(string)
+
+	for _, filter := range filters {
+		partition := duplicateMap(params)
+		// Both filters are parenthesized so that their own OR operators do
+		// not take precedence over the AND operator combining them.
+		if initial != "" {
+			filter = "(" + initial + ") AND (" + filter + ")"
+		}
+		partition["filters"] = filter
+		partitions = append(partitions, partition)
+	}
+
+	return
+}
+
+// browsePartition browses all the records matching the given `params` and
+// sends them to `hits` until the end of the partition is reached, an error
+// occurs or the context is done.
+func browsePartition(ctx context.Context, i Index, params Map, hits chan<- Map, opts *RequestOptions) error {
+	cursor := ""
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		res, err := i.BrowseWithRequestOptions(params, cursor, opts)
+		if err != nil {
+			return err
+		}
+
+		for _, hit := range res.Hits {
+			select {
+			case hits <- hit:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		if res.Cursor == "" {
+			return nil
+		}
+		cursor = res.Cursor
+	}
+}
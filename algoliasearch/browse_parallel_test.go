@@ -0,0 +1,152 @@
+package algoliasearch
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// partitionStubIndex is an Index whose Browse method serves pre-defined pages
+// keyed by the `filters` parameter and the cursor. Calling any other method
+// panics.
+type partitionStubIndex struct {
+	Index
+	mu       sync.Mutex
+	pages    map[string]map[string]BrowseRes
+	requests []string
+}
+
+func (i *partitionStubIndex) BrowseWithRequestOptions(params Map, cursor string, opts *RequestOptions) (res BrowseRes, err error) {
+	filters, _ := params["filters"].(string)
+
+	i.mu.Lock()
+	i.requests = append(i.requests, filters)
+	i.mu.Unlock()
+
+	pages, ok := i.pages[filters]
+	if !ok {
+		err = errors.New("unknown partition: " + filters)
+		return
+	}
+	return pages[cursor], nil
+}
+
+func browseRes(cursor string, objectIDs ...string) (res BrowseRes) {
+	res.Cursor = cursor
+	for _, objectID := range objectIDs {
+		res.Hits = append(res.Hits, Map{"objectID": objectID})
+	}
+	return
+}
+
+func collectObjectIDs(hits <-chan Map, errs <-chan error) (objectIDs []string, err error) {
+	for hit := range hits {
+		objectID, _ := Object(hit).ObjectID()
+		objectIDs = append(objectIDs, objectID)
+	}
+	sort.Strings(objectIDs)
+	return objectIDs, <-errs
+}
+
+func TestNumericRangeFilters(t *testing.T) {
+	require.Nil(t, NumericRangeFilters("price"))
+	require.Equal(t, []string{"price < 10", "price >= 10"}, NumericRangeFilters("price", 10))
+	require.Equal(t,
+		[]string{"price < 10", "price >= 10 AND price < 20.5", "price >= 20.5"},
+		NumericRangeFilters("price", 10, 20.5),
+	)
+}
+
+func TestPartitionParams(t *testing.T) {
+	require.Equal(t, []Map{{"query": "q"}}, partitionParams(Map{"query": "q"}, nil))
+	require.Equal(t,
+		[]Map{{"filters": "brand:apple"}, {"filters": "brand:samsung"}},
+		partitionParams(nil, []string{"brand:apple", "brand:samsung"}),
+	)
+	require.Equal(t,
+		[]Map{{"filters": "(inStock:true) AND (price < 10)"}, {"filters": "(inStock:true) AND (price >= 10)"}},
+		partitionParams(Map{"filters": "inStock:true"}, NumericRangeFilters("price", 10)),
+	)
+	require.Equal(t,
+		[]Map{
+			{"filters": "(brand:apple OR brand:samsung) AND (price < 10)"},
+			{"filters": "(brand:apple OR brand:samsung) AND (price >= 10 AND price < 20)"},
+			{"filters": "(brand:apple OR brand:samsung) AND (price >= 20)"},
+		},
+		partitionParams(Map{"filters": "brand:apple OR brand:samsung"}, NumericRangeFilters("price", 10, 20)),
+	)
+}
+
+func TestBrowseParallel(t *testing.T) {
+	i := &partitionStubIndex{pages: map[string]map[string]BrowseRes{
+		"price < 10": {
+			"":     browseRes("next", "1", "2"),
+			"next": browseRes("", "3"),
+		},
+		"price >= 10": {
+			"": browseRes("", "4", "5"),
+		},
+	}}
+
+	hits, errs := browseParallel(context.Background(), i, nil, BrowseParallelOptions{
+		Filters: NumericRangeFilters("price", 10),
+		Workers: 8,
+	}, nil)
+
+	objectIDs, err := collectObjectIDs(hits, errs)
+	require.NoError(t, err)
+	require.Equal(t, []string{"1", "2", "3", "4", "5"}, objectIDs)
+	require.Len(t, i.requests, 3)
+}
+
+func TestBrowseParallel_error(t *testing.T) {
+	i := &partitionStubIndex{pages: map[string]map[string]BrowseRes{
+		"brand:apple": {"": browseRes("", "1")},
+	}}
+
+	hits, errs := browseParallel(context.Background(), i, nil, BrowseParallelOptions{
+		Filters: []string{"brand:apple", "brand:unknown"},
+		Workers: 1,
+	}, nil)
+
+	_, err := collectObjectIDs(hits, errs)
+	require.EqualError(t, err, "unknown partition: brand:unknown")
+}
+
+func TestBrowseParallel_invalidParams(t *testing.T) {
+	c := NewClient("appID", "apiKey")
+	i := c.InitIndex("products")
+
+	hits, errs := i.BrowseParallel(context.Background(), Map{"filters": 42}, BrowseParallelOptions{})
+	objectIDs, err := collectObjectIDs(hits, errs)
+	require.Error(t, err)
+	require.Empty(t, objectIDs)
+
+	c.SetValidationPolicy(ValidationPolicy{Mode: StrictValidation})
+	hits, errs = i.BrowseParallel(context.Background(), Map{"filter": "brand:apple"}, BrowseParallelOptions{})
+	objectIDs, err = collectObjectIDs(hits, errs)
+	require.EqualError(t, err, "unknown query parameter `filter`: did you mean `filters`?")
+	require.Empty(t, objectIDs)
+}
+
+func TestBrowseParallel_cancel(t *testing.T) {
+	i := &partitionStubIndex{pages: map[string]map[string]BrowseRes{
+		"": {"": browseRes("", "1", "2", "3")},
+	}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	hits, errs := browseParallel(ctx, i, nil, BrowseParallelOptions{}, nil)
+
+	<-hits
+	cancel()
+
+	// As nothing reads the hits anymore, the worker can only notice the
+	// cancellation.
+	require.Equal(t, context.Canceled, <-errs)
+	_, ok := <-hits
+	require.False(t, ok)
+}
@@ -0,0 +1,79 @@
+package algoliasearch
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// checkAnalyticsParams returns an error if the index is missing or if the
+// date range is invalid.
+func checkAnalyticsParams(params AnalyticsParams) error {
+	if params.Index == "" {
+		return emptyField("AnalyticsParams.Index")
+	}
+
+	if !params.StartDate.IsZero() && !params.EndDate.IsZero() && params.EndDate.Before(params.StartDate) {
+		return errors.New("AnalyticsParams: EndDate cannot be before StartDate")
+	}
+
+	if params.Limit < 0 || params.Offset < 0 {
+		return errors.New("AnalyticsParams: Limit and Offset cannot be negative")
+	}
+
+	return nil
+}
+
+func checkGetABTestsParams(params GetABTestsParams) error {
+	if params.Limit < 0 || params.Offset < 0 {
+		return errors.New("GetABTestsParams: Limit and Offset cannot be negative")
+	}
+
+	return nil
+}
+
+// checkABTest returns an error if the AB Test is incomplete, if the traffic
+// percentages of its variants do not add up to 100, if its custom search
+// parameters are rejected by `checkParams` or if it ends before `now`.
+func checkABTest(abTest ABTest, now time.Time, checkParams func(Map) error) error {
+	if abTest.Name == "" {
+		return emptyField("ABTest.Name")
+	}
+
+	if len(abTest.Variants) != 2 {
+		return fmt.Errorf("ABTest.Variants: expected 2 variants but got %d", len(abTest.Variants))
+	}
+
+	total := 0
+	for i, variant := range abTest.Variants {
+		if variant.Index == "" {
+			return emptyField(fmt.Sprintf("ABTest.Variants[%d].Index", i))
+		}
+		if variant.TrafficPercentage <= 0 || variant.TrafficPercentage >= 100 {
+			return fmt.Errorf("ABTest.Variants[%d].TrafficPercentage: should be between 1 and 99 but got %d", i, variant.TrafficPercentage)
+		}
+		total += variant.TrafficPercentage
+
+		if variant.CustomSearchParameters != nil {
+			if i == 0 {
+				return errors.New("ABTest.Variants[0].CustomSearchParameters: only the second variant can have custom search parameters")
+			}
+			if variant.Index != abTest.Variants[0].Index {
+				return fmt.Errorf("ABTest.Variants[%d].Index: a variant with custom search parameters should target the same index as the first variant", i)
+			}
+			if err := checkParams(variant.CustomSearchParameters); err != nil {
+				return fmt.Errorf("ABTest.Variants[%d].CustomSearchParameters: %s", i, err)
+			}
+		}
+	}
+
+	if total != 100 {
+		return fmt.Errorf("ABTest.Variants: traffic percentages should add up to 100 but add up to %d", total)
+	}
+
+	if !abTest.EndAt.After(now) {
+		return fmt.Errorf("ABTest.EndAt: should be in the future but is %s", abTest.EndAt.Format(ISO8601))
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package algoliasearch
+
+import (
+	"fmt"
+	"regexp"
+)
+
+const (
+	maxInsightsEventsPerRequest = 1000
+	maxInsightsEventNameLength  = 64
+	maxInsightsUserTokenLength  = 129
+	maxInsightsObjectIDs        = 20
+	maxInsightsFilters          = 10
+)
+
+var insightsUserTokenRegexp = regexp.MustCompile(`^[a-zA-Z0-9_=/+-]+$`)
+
+func checkInsightsEvents(events []InsightsEvent) error {
+	for _, event := range events {
+		if err := checkInsightsEvent(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkInsightsEvent(event InsightsEvent) error {
+	switch event.EventType {
+	case InsightsClickEvent, InsightsConversionEvent, InsightsViewEvent:
+		// OK
+	default:
+		return fmt.Errorf("InsightsEvent.EventType: unsupported event type %q", event.EventType)
+	}
+
+	if event.EventName == "" {
+		return emptyField("InsightsEvent.EventName")
+	}
+	if len(event.EventName) > maxInsightsEventNameLength {
+		return fmt.Errorf("InsightsEvent.EventName: should not exceed %d characters", maxInsightsEventNameLength)
+	}
+
+	if event.Index == "" {
+		return emptyField("InsightsEvent.Index")
+	}
+
+	if event.UserToken == "" {
+		return emptyField("InsightsEvent.UserToken")
+	}
+	if len(event.UserToken) > maxInsightsUserTokenLength || !insightsUserTokenRegexp.MatchString(event.UserToken) {
+		return fmt.Errorf("InsightsEvent.UserToken: should be at most %d alphanumeric, `_`, `=`, `/`, `+` or `-` characters", maxInsightsUserTokenLength)
+	}
+
+	switch {
+	case len(event.ObjectIDs) > 0 && len(event.Filters) > 0:
+		return fmt.Errorf("InsightsEvent: ObjectIDs and Filters cannot be both set")
+	case len(event.ObjectIDs) == 0 && len(event.Filters) == 0:
+		return fmt.Errorf("InsightsEvent: either ObjectIDs or Filters should be set")
+	case len(event.ObjectIDs) > maxInsightsObjectIDs:
+		return fmt.Errorf("InsightsEvent.ObjectIDs: should not exceed %d elements", maxInsightsObjectIDs)
+	case len(event.Filters) > maxInsightsFilters:
+		return fmt.Errorf("InsightsEvent.Filters: should not exceed %d elements", maxInsightsFilters)
+	}
+
+	if len(event.Positions) > 0 {
+		if event.EventType != InsightsClickEvent || event.QueryID == "" {
+			return fmt.Errorf("InsightsEvent.Positions: only valid for click events related to a QueryID")
+		}
+		if len(event.Positions) != len(event.ObjectIDs) {
+			return fmt.Errorf("InsightsEvent.Positions: should have as many elements as ObjectIDs")
+		}
+	} else if event.EventType == InsightsClickEvent && event.QueryID != "" {
+		return emptyField("InsightsEvent.Positions")
+	}
+
+	return nil
+}
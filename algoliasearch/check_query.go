@@ -1,5 +1,7 @@
 package algoliasearch
 
+import "fmt"
+
 func checkQuery(query Map, ignore ...string) error {
 Outer:
 	for k, v := range query {
@@ -11,8 +13,19 @@ Outer:
 		}
 
 		switch k {
-		case "aroundLatLng",
-			"exactOnSingleWordQuery",
+		case "aroundLatLng":
+			switch v := v.(type) {
+			case string:
+				// OK
+			case GeoPoint:
+				if err := v.Validate(); err != nil {
+					return fmt.Errorf("`%s`: %s", k, err)
+				}
+			default:
+				return invalidType(k, "string or GeoPoint")
+			}
+
+		case "exactOnSingleWordQuery",
 			"filters",
 			"highlightPostTag",
 			"highlightPreTag",
@@ -81,11 +94,15 @@ Outer:
 			}
 
 		case "aroundRadius":
-			switch v.(type) {
+			switch v := v.(type) {
 			case int, string:
 				// OK
+			case Radius:
+				if err := v.Validate(); err != nil {
+					return fmt.Errorf("`%s`: %s", k, err)
+				}
 			default:
-				return invalidType(k, "int or string")
+				return invalidType(k, "int, string or Radius")
 			}
 
 		case "getRankingInfo":
@@ -124,13 +141,40 @@ Outer:
 				return invalidType(k, "string or []string")
 			}
 
-		case "insideBoundingBox",
-			"insidePolygon":
-			switch v.(type) {
+		case "insideBoundingBox":
+			switch v := v.(type) {
+			case string, [][]float64:
+				// OK
+			case BoundingBox:
+				if err := v.Validate(); err != nil {
+					return fmt.Errorf("`%s`: %s", k, err)
+				}
+			case []BoundingBox:
+				for _, b := range v {
+					if err := b.Validate(); err != nil {
+						return fmt.Errorf("`%s`: %s", k, err)
+					}
+				}
+			default:
+				return invalidType(k, "string, [][]float64, BoundingBox or []BoundingBox")
+			}
+
+		case "insidePolygon":
+			switch v := v.(type) {
 			case string, [][]float64:
 				// OK
+			case Polygon:
+				if err := v.Validate(); err != nil {
+					return fmt.Errorf("`%s`: %s", k, err)
+				}
+			case []Polygon:
+				for _, p := range v {
+					if err := p.Validate(); err != nil {
+						return fmt.Errorf("`%s`: %s", k, err)
+					}
+				}
 			default:
-				return invalidType(k, "string or [][]float64")
+				return invalidType(k, "string, [][]float64, Polygon or []Polygon")
 			}
 
 		case "typoTolerance":
@@ -16,3 +16,32 @@ func TestCheckQuery(t *testing.T) {
 		require.NoError(t, checkQuery(m), "should accept the following query parameter: %#v", m)
 	}
 }
+
+func TestCheckQuery_geo(t *testing.T) {
+	paris := GeoPoint{Lat: 48.8566, Lng: 2.3522}
+	london := GeoPoint{Lat: 51.5074, Lng: -0.1278}
+	berlin := GeoPoint{Lat: 52.52, Lng: 13.405}
+
+	for _, m := range []Map{
+		{"aroundLatLng": paris},
+		{"aroundRadius": NewRadius(1000)},
+		{"aroundRadius": RadiusAll},
+		{"insideBoundingBox": BoundingBox{paris, london}},
+		{"insideBoundingBox": []BoundingBox{{paris, london}, {london, berlin}}},
+		{"insidePolygon": Polygon{paris, london, berlin}},
+		{"insidePolygon": []Polygon{{paris, london, berlin}}},
+	} {
+		require.NoError(t, checkQuery(m), "should accept the following query parameter: %#v", m)
+	}
+
+	for _, m := range []Map{
+		{"aroundLatLng": GeoPoint{Lat: 91, Lng: 0}},
+		{"aroundLatLng": GeoPoint{Lat: 0, Lng: -181}},
+		{"aroundRadius": NewRadius(0)},
+		{"insideBoundingBox": BoundingBox{paris, GeoPoint{Lat: 100}}},
+		{"insidePolygon": Polygon{paris, london}},
+		{"insidePolygon": []Polygon{{paris, london, GeoPoint{Lng: 200}}}},
+	} {
+		require.Error(t, checkQuery(m), "should reject the following query parameter: %#v", m)
+	}
+}
@@ -0,0 +1,70 @@
+package algoliasearch
+
+import (
+	"fmt"
+	"strings"
+)
+
+func checkReplicasConfig(primary string, config ReplicasConfig) error {
+	names := make(map[string]bool)
+
+	for _, replica := range config.Replicas {
+		if replica.Name == "" {
+			return emptyField("Replica.Name")
+		}
+		if replica.Name == primary {
+			return fmt.Errorf("index `%s` cannot be a replica of itself", primary)
+		}
+		if names[replica.Name] {
+			return fmt.Errorf("replica `%s` is declared more than once", replica.Name)
+		}
+		names[replica.Name] = true
+
+		for _, criterion := range replica.Sort {
+			if sortCriterionAttribute(criterion) == "" {
+				return fmt.Errorf("sort criterion `%s` of replica `%s` should be `asc(attribute)` or `desc(attribute)`", criterion, replica.Name)
+			}
+		}
+
+		if replica.Settings == nil {
+			continue
+		}
+
+		settings := replica.Settings.ToMap()
+		for _, k := range []string{"replicas", "slaves", "primary"} {
+			if _, ok := settings[k]; ok {
+				return fmt.Errorf("`%s` cannot be set on replica `%s`", k, replica.Name)
+			}
+		}
+		if _, ok := settings["ranking"]; ok && len(replica.Sort) > 0 {
+			return fmt.Errorf("`ranking` cannot be set on replica `%s` as it is computed from its sort criteria", replica.Name)
+		}
+		if err := checkSettings(settings); err != nil {
+			return err
+		}
+	}
+
+	for _, k := range config.PropagatedSettings {
+		switch k {
+		case "ranking", "replicas", "slaves", "primary":
+			return fmt.Errorf("`%s` cannot be propagated to the replicas", k)
+		}
+		if !knownSettings[k] {
+			return unknownKey("propagated setting", k, knownSettings)
+		}
+	}
+
+	return nil
+}
+
+// sortCriterionAttribute returns the attribute of the given `asc(attribute)`
+// or `desc(attribute)` criterion, or an empty string if the criterion is
+// malformed.
+func sortCriterionAttribute(criterion string) string {
+	for _, order := range []string{"asc(", "desc("} {
+		if strings.HasPrefix(criterion, order) && strings.HasSuffix(criterion, ")") {
+			return criterion[len(order) : len(criterion)-1]
+		}
+	}
+	return ""
+}
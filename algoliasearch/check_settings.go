@@ -2,8 +2,14 @@ package algoliasearch
 
 func checkSettings(settings Map) error {
 	for k, v := range settings {
+		// A nil value resets the setting to its default value.
+		if v == nil {
+			continue
+		}
+
 		switch k {
-		case "alternativesAsExact",
+		case "advancedSyntaxFeatures",
+			"alternativesAsExact",
 			"attributesForFaceting",
 			"attributesToHighlight",
 			"attributesToIndex",
@@ -15,6 +21,7 @@ func checkSettings(settings Map) error {
 			"disablePrefixOnAttributes",
 			"disableTypoToleranceOnAttributes",
 			"disableTypoToleranceOnWords",
+			"indexLanguages",
 			"numericAttributesForFiltering",
 			"numericAttributesToIndex",
 			"ranking",
@@ -30,6 +37,8 @@ func checkSettings(settings Map) error {
 		case "allowCompressionOfIntegerArray",
 			"advancedSyntax",
 			"allowTyposOnNumericTokens",
+			"attributeCriteriaComputedByMinProximity",
+			"enablePersonalization",
 			"enableRules",
 			"replaceSynonymsInHighlight",
 			"forwardToSlaves",
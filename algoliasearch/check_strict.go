@@ -0,0 +1,275 @@
+package algoliasearch
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// knownSettings are the keys accepted by `SetSettings`: the settings of the
+// Settings structure, except the read-only `primary` one, and the
+// `forwardToReplicas` flag.
+var knownSettings = settingsKeys()
+
+func settingsKeys() map[string]bool {
+	keys := map[string]bool{
+		"forwardToReplicas": true,
+		"forwardToSlaves":   true,
+	}
+
+	t := reflect.TypeOf(Settings{})
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name != "" && name != "primary" {
+			keys[name] = true
+		}
+	}
+
+	return keys
+}
+
+// knownQueryParameters are the keys accepted as query parameters by the
+// search, browse and delete-by methods.
+var knownQueryParameters = map[string]bool{
+	"advancedSyntax":                          true,
+	"advancedSyntaxFeatures":                  true,
+	"allowTyposOnNumericTokens":               true,
+	"alternativesAsExact":                     true,
+	"analytics":                               true,
+	"analyticsTags":                           true,
+	"aroundLatLng":                            true,
+	"aroundLatLngViaIP":                       true,
+	"aroundPrecision":                         true,
+	"aroundRadius":                            true,
+	"attributeCriteriaComputedByMinProximity": true,
+	"attributesToHighlight":                   true,
+	"attributesToRetrieve":                    true,
+	"attributesToSnippet":                     true,
+	"clickAnalytics":                          true,
+	"disableExactOnAttributes":                true,
+	"disableTypoToleranceOnAttributes":        true,
+	"distinct":                                true,
+	"enableABTest":                            true,
+	"enablePersonalization":                   true,
+	"enableRules":                             true,
+	"exactOnSingleWordQuery":                  true,
+	"explain":                                 true,
+	"facetFilters":                            true,
+	"facetingAfterDistinct":                   true,
+	"facets":                                  true,
+	"filters":                                 true,
+	"getRankingInfo":                          true,
+	"highlightPostTag":                        true,
+	"highlightPreTag":                         true,
+	"hitsPerPage":                             true,
+	"ignorePlurals":                           true,
+	"insideBoundingBox":                       true,
+	"insidePolygon":                           true,
+	"length":                                  true,
+	"maxFacetHits":                            true,
+	"maxValuesPerFacet":                       true,
+	"minimumAroundRadius":                     true,
+	"minProximity":                            true,
+	"minWordSizefor1Typo":                     true,
+	"minWordSizefor2Typos":                    true,
+	"numericFilters":                          true,
+	"offset":                                  true,
+	"optionalFilters":                         true,
+	"optionalWords":                           true,
+	"page":                                    true,
+	"percentileComputation":                   true,
+	"query":                                   true,
+	"queryLanguages":                          true,
+	"queryType":                               true,
+	"removeStopWords":                         true,
+	"removeWordsIfNoResults":                  true,
+	"replaceSynonymsInHighlight":              true,
+	"responseFields":                          true,
+	"restrictHighlightAndSnippetArrays":       true,
+	"restrictSearchableAttributes":            true,
+	"restrictSources":                         true,
+	"ruleContexts":                            true,
+	"snippetEllipsisText":                     true,
+	"sortFacetValuesBy":                       true,
+	"sumOrFiltersScores":                      true,
+	"synonyms":                                true,
+	"tagFilters":                              true,
+	"typoTolerance":                           true,
+	"userToken":                               true,
+}
+
+// rankingCriteria are the built-in criteria of the `ranking` setting, which
+// also accepts `asc(attribute)` and `desc(attribute)` criteria.
+var rankingCriteria = map[string]bool{
+	"attribute": true,
+	"custom":    true,
+	"exact":     true,
+	"filters":   true,
+	"geo":       true,
+	"proximity": true,
+	"typo":      true,
+	"words":     true,
+}
+
+// valueDomains are the values accepted by the settings and query parameters
+// whose string values are enumerations.
+var valueDomains = map[string][]string{
+	"exactOnSingleWordQuery": {"attribute", "none", "word"},
+	"queryType":              {"prefixAll", "prefixLast", "prefixNone"},
+	"removeWordsIfNoResults": {"allOptional", "firstWords", "lastWords", "none"},
+	"sortFacetValuesBy":      {"alpha", "count"},
+	"typoTolerance":          {"false", "min", "strict", "true"},
+}
+
+// checkSettingsStrictly reports the unknown keys of `settings` and the values
+// outside of their domain. It is meant to be called once the types have
+// been checked by `checkSettings`.
+func checkSettingsStrictly(settings Map) error {
+	for _, k := range sortedMapKeys(settings) {
+		if !knownSettings[k] {
+			return unknownKey("setting", k, knownSettings)
+		}
+
+		if err := checkValueDomain(k, settings[k]); err != nil {
+			return err
+		}
+
+		switch k {
+		case "ranking":
+			ranking, _ := settings[k].([]string)
+			for _, criterion := range ranking {
+				if !rankingCriteria[criterion] && sortCriterionAttribute(criterion) == "" {
+					return fmt.Errorf("invalid `ranking` criterion `%s`: should be one of %s, `asc(attribute)` or `desc(attribute)`", criterion, quoteKeys(rankingCriteria))
+				}
+			}
+
+		case "customRanking":
+			customRanking, _ := settings[k].([]string)
+			for _, criterion := range customRanking {
+				if sortCriterionAttribute(criterion) == "" {
+					return fmt.Errorf("invalid `customRanking` criterion `%s`: should be `asc(attribute)` or `desc(attribute)`", criterion)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkQueryStrictly reports the unknown keys of `query` and the values
+// outside of their domain. It is meant to be called once the types have been
+// checked by `checkQuery`.
+func checkQueryStrictly(query Map) error {
+	for _, k := range sortedMapKeys(query) {
+		if !knownQueryParameters[k] {
+			return unknownKey("query parameter", k, knownQueryParameters)
+		}
+
+		if err := checkValueDomain(k, query[k]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func checkValueDomain(k string, v interface{}) error {
+	domain, ok := valueDomains[k]
+	if !ok {
+		return nil
+	}
+
+	s, ok := v.(string)
+	if !ok {
+		return nil
+	}
+
+	for _, value := range domain {
+		if s == value {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid `%s` value `%s`: should be one of `%s`", k, s, strings.Join(domain, "`, `"))
+}
+
+// unknownKey returns the error reporting the unknown key `k`, along with the
+// closest known key, if any is close enough to be a likely typo.
+func unknownKey(kind, k string, known map[string]bool) error {
+	if suggestion := closestKey(k, known); suggestion != "" {
+		return fmt.Errorf("unknown %s `%s`: did you mean `%s`?", kind, k, suggestion)
+	}
+	return fmt.Errorf("unknown %s `%s`", kind, k)
+}
+
+// closestKey returns the known key with the smallest case-insensitive edit
+// distance to `k`, or an empty string if none is within a third of the
+// length of `k`.
+func closestKey(k string, known map[string]bool) (closest string) {
+	best := len(k)/3 + 1
+
+	for _, candidate := range sortedNames(known) {
+		if d := editDistance(strings.ToLower(k), strings.ToLower(candidate)); d < best {
+			best, closest = d, candidate
+		}
+	}
+
+	return
+}
+
+// editDistance returns the Levenshtein distance between `a` and `b`.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(prev[j]+1, minInt(curr[j-1]+1, prev[j-1]+cost))
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// sortedMapKeys returns the keys of `m` in lexicographic order, so that the
+// first reported issue does not depend on the map iteration order.
+func sortedMapKeys(m Map) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedNames(names map[string]bool) []string {
+	keys := make([]string, 0, len(names))
+	for k := range names {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func quoteKeys(keys map[string]bool) string {
+	return "`" + strings.Join(sortedNames(keys), "`, `") + "`"
+}
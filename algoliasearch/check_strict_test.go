@@ -0,0 +1,147 @@
+package algoliasearch
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckSettingsStrictly(t *testing.T) {
+	for _, m := range []Map{
+		{"attributesForFaceting": []string{"brand"}, "forwardToReplicas": true},
+		{"queryType": "prefixNone", "typoTolerance": "min", "removeWordsIfNoResults": "lastWords"},
+		{"ranking": []string{"desc(date)", "typo", "geo", "words", "filters", "proximity", "attribute", "exact", "custom"}},
+		{"customRanking": []string{"desc(popularity)", "asc(price)"}},
+		{"typoTolerance": true},
+	} {
+		require.NoError(t, checkSettingsStrictly(m), "should accept the following settings: %#v", m)
+	}
+
+	for _, c := range []struct {
+		settings Map
+		msg      string
+	}{
+		{Map{"attributesForFacetting": []string{"brand"}}, "unknown setting `attributesForFacetting`: did you mean `attributesForFaceting`?"},
+		{Map{"hitsperpage": 10}, "unknown setting `hitsperpage`: did you mean `hitsPerPage`?"},
+		{Map{"foo": 1}, "unknown setting `foo`"},
+		{Map{"primary": "products"}, "unknown setting `primary`"},
+		{Map{"queryType": "prefixFirst"}, "invalid `queryType` value `prefixFirst`: should be one of `prefixAll`, `prefixLast`, `prefixNone`"},
+		{Map{"ranking": []string{"typo", "popularity"}}, "invalid `ranking` criterion `popularity`: should be one of `attribute`, `custom`, `exact`, `filters`, `geo`, `proximity`, `typo`, `words`, `asc(attribute)` or `desc(attribute)`"},
+		{Map{"customRanking": []string{"popularity"}}, "invalid `customRanking` criterion `popularity`: should be `asc(attribute)` or `desc(attribute)`"},
+		{Map{"customRanking": []string{"desc(popularity"}}, "invalid `customRanking` criterion `desc(popularity`: should be `asc(attribute)` or `desc(attribute)`"},
+	} {
+		require.EqualError(t, checkSettingsStrictly(c.settings), c.msg)
+	}
+}
+
+func TestCheckQueryStrictly(t *testing.T) {
+	require.NoError(t, checkQueryStrictly(Map{
+		"query":          "phone",
+		"hitsPerPage":    10,
+		"facetFilters":   []string{"brand:apple"},
+		"queryType":      "prefixAll",
+		"ruleContexts":   []string{"mobile"},
+		"getRankingInfo": true,
+	}))
+
+	err := checkQueryStrictly(Map{"query": "phone", "hitPerPage": 10})
+	require.EqualError(t, err, "unknown query parameter `hitPerPage`: did you mean `hitsPerPage`?")
+
+	err = checkQueryStrictly(Map{"sortFacetValuesBy": "name"})
+	require.EqualError(t, err, "invalid `sortFacetValuesBy` value `name`: should be one of `alpha`, `count`")
+}
+
+// TestKnownKeys checks that all the keys whose types are checked by
+// `checkSettings` and `checkQuery` are known in strict mode.
+func TestKnownKeys(t *testing.T) {
+	for _, c := range []struct {
+		filename string
+		known    map[string]bool
+	}{
+		{"check_settings.go", knownSettings},
+		{"check_query.go", knownQueryParameters},
+	} {
+		f, err := parser.ParseFile(token.NewFileSet(), c.filename, nil, 0)
+		require.NoError(t, err)
+
+		var keys []string
+		ast.Inspect(f, func(n ast.Node) bool {
+			clause, ok := n.(*ast.CaseClause)
+			if !ok {
+				return true
+			}
+			for _, expr := range clause.List {
+				if lit, ok := expr.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+					k, err := strconv.Unquote(lit.Value)
+					require.NoError(t, err)
+					keys = append(keys, k)
+				}
+			}
+			return true
+		})
+
+		require.NotEmpty(t, keys)
+		for _, k := range keys {
+			require.True(t, c.known[k], "`%s` is accepted by %s but unknown in strict mode", k, c.filename)
+		}
+	}
+}
+
+func TestClient_SetValidationPolicy(t *testing.T) {
+	rt := &recordingRoundTripper{response: `{"taskID": 1, "hits": []}`}
+	c := NewClient("appID", "apiKey")
+	c.SetHTTPClient(&http.Client{Transport: rt})
+	i := c.InitIndex("products")
+
+	settings := Map{"attributesForFacetting": []string{"brand"}}
+	params := Map{"hitPerPage": 10}
+
+	t.Log("TestClient_SetValidationPolicy: Unknown keys are sent by default")
+	{
+		_, err := i.SetSettings(settings)
+		require.NoError(t, err)
+		_, err = i.Search("", params)
+		require.NoError(t, err)
+		require.Len(t, rt.requests, 2)
+	}
+
+	t.Log("TestClient_SetValidationPolicy: Unknown keys are reported but still sent")
+	{
+		var warnings []string
+		c.SetValidationPolicy(ValidationPolicy{
+			Mode: WarnValidation,
+			Warn: func(err error) { warnings = append(warnings, err.Error()) },
+		})
+		_, err := i.SetSettings(settings)
+		require.NoError(t, err)
+		_, err = i.Search("", params)
+		require.NoError(t, err)
+		require.Len(t, rt.requests, 4)
+		require.Equal(t, []string{
+			"unknown setting `attributesForFacetting`: did you mean `attributesForFaceting`?",
+			"unknown query parameter `hitPerPage`: did you mean `hitsPerPage`?",
+		}, warnings)
+	}
+
+	t.Log("TestClient_SetValidationPolicy: Unknown keys are rejected")
+	{
+		c.SetValidationPolicy(ValidationPolicy{Mode: StrictValidation})
+		_, err := i.SetSettings(settings)
+		require.Error(t, err)
+		_, err = i.Search("", params)
+		require.Error(t, err)
+		_, err = c.MultipleQueries([]IndexedQuery{{IndexName: "products", Params: params}}, "")
+		require.Error(t, err)
+		require.Len(t, rt.requests, 4)
+
+		_, err = i.SetSettings(Map{"attributesForFaceting": []string{"brand"}, "forwardToReplicas": true})
+		require.NoError(t, err)
+		_, err = i.Search("phone", Map{"hitsPerPage": 10})
+		require.NoError(t, err)
+	}
+}
@@ -0,0 +1,54 @@
+package algoliasearch
+
+import "fmt"
+
+func checkSynonyms(synonyms []Synonym) error {
+	for _, synonym := range synonyms {
+		if err := checkSynonym(synonym); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkSynonym(synonym Synonym) error {
+	if synonym.ObjectID == "" {
+		return emptyField("Synonym.ObjectID")
+	}
+
+	switch synonym.Type {
+	case "synonym":
+		if len(synonym.Synonyms) == 0 {
+			return emptyField("Synonym.Synonyms")
+		}
+
+	case "oneWaySynonym":
+		if synonym.Input == "" {
+			return emptyField("Synonym.Input")
+		}
+		if len(synonym.Synonyms) == 0 {
+			return emptyField("Synonym.Synonyms")
+		}
+
+	case AltCorrection1, AltCorrection2:
+		if synonym.Word == "" {
+			return emptyField("Synonym.Word")
+		}
+		if len(synonym.Corrections) == 0 {
+			return emptyField("Synonym.Corrections")
+		}
+
+	case "placeholder":
+		if synonym.Placeholder == "" {
+			return emptyField("Synonym.Placeholder")
+		}
+		if len(synonym.Replacements) == 0 {
+			return emptyField("Synonym.Replacements")
+		}
+
+	default:
+		return fmt.Errorf("Synonym.Type: unsupported synonym type %q", synonym.Type)
+	}
+
+	return nil
+}
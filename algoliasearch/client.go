@@ -10,14 +10,21 @@ import (
 )
 
 type client struct {
-	transport *Transport
+	transport     *Transport
+	waitPolicy    WaitPolicy
+	responseCache ResponseCache
+	requestGroup  *requestGroup
+
+	validationPolicy      ValidationPolicy
+	settingsSnapshotStore SettingsSnapshotStore
 }
 
 // NewClient instantiates a new `Client` from the provided `appID` and
 // `apiKey`. Default hosts are used for the transport layer.
 func NewClient(appID, apiKey string) Client {
 	return &client{
-		transport: NewTransport(appID, apiKey),
+		transport:  NewTransport(appID, apiKey),
+		waitPolicy: DefaultWaitPolicy,
 	}
 }
 
@@ -26,7 +33,8 @@ func NewClient(appID, apiKey string) Client {
 // `hosts`.
 func NewClientWithHosts(appID, apiKey string, hosts []string) Client {
 	return &client{
-		transport: NewTransportWithHosts(appID, apiKey, hosts),
+		transport:  NewTransportWithHosts(appID, apiKey, hosts),
+		waitPolicy: DefaultWaitPolicy,
 	}
 }
 
@@ -52,18 +60,55 @@ func (c *client) SetHTTPClient(client *http.Client) {
 	c.transport.httpClient = client
 }
 
+func (c *client) SetWaitPolicy(policy WaitPolicy) {
+	c.waitPolicy = policy
+}
+
+func (c *client) SetResponseCache(cache ResponseCache) {
+	c.responseCache = cache
+}
+
+func (c *client) SetRequestDeduplication(enabled bool) {
+	if enabled {
+		c.requestGroup = newRequestGroup()
+	} else {
+		c.requestGroup = nil
+	}
+}
+
+func (c *client) SetValidationPolicy(policy ValidationPolicy) {
+	c.validationPolicy = policy
+}
+
+func (c *client) SetSettingsSnapshotStore(store SettingsSnapshotStore) {
+	c.settingsSnapshotStore = store
+}
+
 func (c *client) ListIndexes() (indexes []IndexRes, err error) {
 	return c.ListIndexesWithRequestOptions(nil)
 }
 
 func (c *client) ListIndexesWithRequestOptions(opts *RequestOptions) (indexes []IndexRes, err error) {
-	var res listIndexesRes
+	var res ListIndexesRes
 
 	err = c.request(&res, "GET", "/1/indexes", nil, read, opts)
 	indexes = res.Items
 	return
 }
 
+func (c *client) ListIndexesPage(page int, hitsPerPage int) (res ListIndexesRes, err error) {
+	return c.ListIndexesPageWithRequestOptions(page, hitsPerPage, nil)
+}
+
+func (c *client) ListIndexesPageWithRequestOptions(page int, hitsPerPage int, opts *RequestOptions) (res ListIndexesRes, err error) {
+	params := Map{
+		"page":        page,
+		"hitsPerPage": hitsPerPage,
+	}
+	err = c.request(&res, "GET", "/1/indexes?"+encodeMap(params), nil, read, opts)
+	return
+}
+
 func (c *client) InitIndex(name string) Index {
 	return NewIndex(name, c)
 }
@@ -72,6 +117,10 @@ func (c *client) InitAnalytics() Analytics {
 	return NewAnalytics(c)
 }
 
+func (c *client) InitInsights() Insights {
+	return NewInsights(c)
+}
+
 func (c *client) ListKeys() (keys []Key, err error) {
 	return c.ListAPIKeys()
 }
@@ -228,7 +277,7 @@ func (c *client) MultipleQueriesWithRequestOptions(queries []IndexedQuery, strat
 	}
 
 	for _, q := range queries {
-		if err = checkQuery(q.Params); err != nil {
+		if err = c.checkQuery(q.Params); err != nil {
 			return
 		}
 	}
@@ -247,7 +296,7 @@ func (c *client) MultipleQueriesWithRequestOptions(queries []IndexedQuery, strat
 	}
 
 	var m multipleQueriesRes
-	err = c.request(&m, "POST", "/1/indexes/*/queries", body, search, opts)
+	err = c.cachedRequest(&m, "POST", "/1/indexes/*/queries", body, search, opts)
 	res = m.Results
 	return
 }
@@ -352,6 +401,7 @@ func (c *client) BatchWithRequestOptions(operations []BatchOperationIndexed, opt
 	}
 
 	err = c.request(&res, "POST", "/1/indexes/*/batch", request, write, opts)
+	res.bind(c, "", opts)
 	return
 }
 
@@ -360,30 +410,15 @@ func (c *client) WaitTask(indexName string, taskID int) error {
 }
 
 func (c *client) WaitTaskWithRequestOptions(indexName string, taskID int, opts *RequestOptions) error {
-	var maxDuration = time.Second
-
-	for {
-		res, err := c.GetStatusWithRequestOptions(indexName,
-			taskID, opts)
-		if err != nil {
-			return err
-		}
-
-		if res.Status == "published" {
-			return nil
-		}
+	return c.waitTask(opts.context(), indexName, taskID, opts)
+}
 
-		sleepDuration := randDuration(maxDuration)
-		time.Sleep(sleepDuration)
+func (c *client) WaitTasks(tasks []IndexedTask) error {
+	return c.WaitTasksWithRequestOptions(tasks, nil)
+}
 
-		// Increase the upper boundary used to generate the sleep duration
-		if maxDuration < 10*time.Minute {
-			maxDuration *= 2
-			if maxDuration > 10*time.Minute {
-				maxDuration = 10 * time.Minute
-			}
-		}
-	}
+func (c *client) WaitTasksWithRequestOptions(tasks []IndexedTask, opts *RequestOptions) error {
+	return c.waitTasks(opts.context(), tasks, opts)
 }
 
 func (c *client) GetStatus(indexName string, taskID int) (res TaskStatusRes, err error) {
@@ -397,10 +432,67 @@ func (c *client) GetStatusWithRequestOptions(indexName string, taskID int, opts
 }
 
 func (c *client) request(res interface{}, method, path string, body interface{}, typeCall int, opts *RequestOptions) error {
-	r, err := c.transport.request(method, path, body, typeCall, opts)
+	r, err := c.transportRequest(method, path, body, typeCall, opts)
 	if err != nil {
 		return err
 	}
 
 	return json.Unmarshal(r, res)
 }
+
+// transportRequest performs the request through the transport layer. If
+// request deduplication is enabled, identical search and read requests which
+// are in flight at the same time share a single round trip. The returned raw
+// response may then be shared and must not be modified. Requests bound to
+// their own Context are never deduplicated, so that cancelling one of them
+// does not interrupt the others.
+func (c *client) transportRequest(method, path string, body interface{}, typeCall int, opts *RequestOptions) ([]byte, error) {
+	group := c.requestGroup
+	if group == nil || (typeCall != search && typeCall != read) || (opts != nil && opts.Context != nil) {
+		return c.transport.request(method, path, body, typeCall, opts)
+	}
+
+	key, err := c.transport.cacheKey(method, path, body, opts)
+	if err != nil {
+		return c.transport.request(method, path, body, typeCall, opts)
+	}
+
+	return group.do(key, func() ([]byte, error) {
+		return c.transport.request(method, path, body, typeCall, opts)
+	})
+}
+
+// cachedRequest is the same as request but the response is first looked up
+// in the ResponseCache of the client, if any, unless the RequestOptions ask to
+// bypass it. Successful responses are then stored in the cache, which lets
+// bypassing requests refresh it.
+func (c *client) cachedRequest(res interface{}, method, path string, body interface{}, typeCall int, opts *RequestOptions) error {
+	cache := c.responseCache
+	if cache == nil {
+		return c.request(res, method, path, body, typeCall, opts)
+	}
+
+	key, err := c.transport.cacheKey(method, path, body, opts)
+	if err != nil {
+		return c.request(res, method, path, body, typeCall, opts)
+	}
+
+	if opts == nil || !opts.BypassCache {
+		if r, ok := cache.Get(key); ok {
+			debug("* CACHE HIT [%s] path=%s", method, path)
+			return json.Unmarshal(r, res)
+		}
+	}
+
+	r, err := c.transportRequest(method, path, body, typeCall, opts)
+	if err != nil {
+		return err
+	}
+
+	if err = json.Unmarshal(r, res); err != nil {
+		return err
+	}
+
+	cache.Set(key, r)
+	return nil
+}
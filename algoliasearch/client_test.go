@@ -356,11 +356,14 @@ func TestBatch(t *testing.T) {
 		{IndexName: "TestBatch_prod", BatchOperation: operation},
 	}
 
-	_, err := c.Batch(operations)
+	res, err := c.Batch(operations)
 
 	if err != nil {
 		t.Fatalf("TestBatch: Cannot batch operations: %s", err)
 	}
+
+	require.Len(t, res.Tasks(), 2, "should return one task per index")
+	require.NoError(t, c.WaitTasks(res.Tasks()), "should wait for all the tasks without error")
 }
 
 func TestSlaveReplica(t *testing.T) {
@@ -0,0 +1,248 @@
+package algoliasearch
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func (i *index) DisjunctiveSearch(query string, params Map, state RefinementState) (res QueryRes, err error) {
+	return i.DisjunctiveSearchWithRequestOptions(query, params, state, nil)
+}
+
+func (i *index) DisjunctiveSearchWithRequestOptions(query string, params Map, state RefinementState, opts *RequestOptions) (res QueryRes, err error) {
+	for _, k := range []string{"facets", "facetFilters", "numericFilters"} {
+		if _, ok := params[k]; ok {
+			err = fmt.Errorf("`%s` cannot be used with DisjunctiveSearch, it is computed from the RefinementState", k)
+			return
+		}
+	}
+
+	if err = checkRefinementState(state); err != nil {
+		return
+	}
+
+	queries := disjunctiveQueries(i.name, query, params, state)
+
+	results, err := i.client.MultipleQueriesWithRequestOptions(queries, "none", opts)
+	if err != nil {
+		return
+	}
+
+	if len(results) != len(queries) {
+		err = fmt.Errorf("DisjunctiveSearch: %d queries sent but %d results received", len(queries), len(results))
+		return
+	}
+
+	res = mergeDisjunctiveResults(results, state)
+	return
+}
+
+// checkRefinementState returns an error if the numeric refinements of the
+// given state are not valid.
+func checkRefinementState(state RefinementState) error {
+	for _, r := range state.NumericRefinements {
+		if r.Attribute == "" {
+			return errors.New("NumericRefinement: `Attribute` cannot be empty")
+		}
+
+		switch r.Operator {
+		case "<", "<=", "=", "!=", ">=", ">":
+			// OK
+		default:
+			return fmt.Errorf("NumericRefinement: unsupported operator %q", r.Operator)
+		}
+	}
+
+	return nil
+}
+
+// refinedDisjunctiveFacets returns, in a deterministic order, the disjunctive
+// facets which need their own query because they are refined, either by
+// facet values or by numeric refinements.
+func refinedDisjunctiveFacets(state RefinementState) (facets []string) {
+	for facet, values := range state.DisjunctiveFacets {
+		refined := len(values) > 0
+		for _, r := range state.NumericRefinements {
+			if r.Attribute == facet {
+				refined = true
+			}
+		}
+
+		if refined {
+			facets = append(facets, facet)
+		}
+	}
+
+	sort.Strings(facets)
+	return
+}
+
+// disjunctiveQueries builds the main query, retrieving the hits and the
+// counts of all the facets, followed by one query per refined disjunctive
+// facet, retrieving the counts of this facet without its own refinements.
+func disjunctiveQueries(indexName, query string, params Map, state RefinementState) []IndexedQuery {
+	var facets []string
+	for facet := range state.ConjunctiveFacets {
+		facets = append(facets, facet)
+	}
+	for facet := range state.DisjunctiveFacets {
+		facets = append(facets, facet)
+	}
+	sort.Strings(facets)
+
+	main := duplicateMap(params)
+	main["query"] = query
+	main["facets"] = facets
+	setRefinementFilters(main, state, "")
+
+	queries := []IndexedQuery{{IndexName: indexName, Params: main}}
+
+	for _, facet := range refinedDisjunctiveFacets(state) {
+		p := duplicateMap(params)
+		p["query"] = query
+		p["facets"] = []string{facet}
+		p["page"] = 0
+		p["hitsPerPage"] = 0
+		p["attributesToRetrieve"] = []string{}
+		p["attributesToHighlight"] = []string{}
+		p["attributesToSnippet"] = []string{}
+		p["analytics"] = false
+		p["clickAnalytics"] = false
+		setRefinementFilters(p, state, facet)
+		queries = append(queries, IndexedQuery{IndexName: indexName, Params: p})
+	}
+
+	return queries
+}
+
+// setRefinementFilters sets the `facetFilters` and `numericFilters`
+// parameters according to the given state, ignoring the refinements of the
+// `excluded` facet, if any.
+func setRefinementFilters(params Map, state RefinementState, excluded string) {
+	var facetFilters [][]string
+
+	for _, facet := range sortedKeys(state.ConjunctiveFacets) {
+		for _, value := range state.ConjunctiveFacets[facet] {
+			facetFilters = append(facetFilters, []string{facetFilter(facet, value)})
+		}
+	}
+
+	for _, facet := range sortedKeys(state.DisjunctiveFacets) {
+		values := state.DisjunctiveFacets[facet]
+		if facet == excluded || len(values) == 0 {
+			continue
+		}
+
+		var or []string
+		for _, value := range values {
+			or = append(or, facetFilter(facet, value))
+		}
+		facetFilters = append(facetFilters, or)
+	}
+
+	var numericFilters []interface{}
+	for _, r := range state.NumericRefinements {
+		if r.Attribute == excluded {
+			continue
+		}
+		numericFilters = append(numericFilters, r.Attribute+r.Operator+strconv.FormatFloat(r.Value, 'f', -1, 64))
+	}
+
+	if len(facetFilters) > 0 {
+		params["facetFilters"] = facetFilters
+	}
+
+	if len(numericFilters) > 0 {
+		params["numericFilters"] = numericFilters
+	}
+}
+
+// facetFilter returns the facet filter matching `value` for `facet`. A leading
+// dash is escaped as it would otherwise negate the filter.
+func facetFilter(facet, value string) string {
+	if strings.HasPrefix(value, "-") {
+		value = `\` + value
+	}
+	return facet + ":" + value
+}
+
+func sortedKeys(m map[string][]string) (keys []string) {
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return
+}
+
+// mergeDisjunctiveResults returns the result of the main query where the
+// counts and stats of each refined disjunctive facet are replaced by the ones
+// of its dedicated query. The refined values are always present in the counts
+// of their facet, even when no hit matches them.
+func mergeDisjunctiveResults(results []MultipleQueryRes, state RefinementState) QueryRes {
+	res := results[0].QueryRes
+
+	facets := make(Map)
+	for k, v := range res.Facets {
+		facets[k] = v
+	}
+
+	stats := make(Map)
+	for k, v := range res.FacetsStats {
+		stats[k] = v
+	}
+
+	for j, facet := range refinedDisjunctiveFacets(state) {
+		sub := results[j+1].QueryRes
+
+		if counts, ok := sub.Facets[facet]; ok {
+			facets[facet] = counts
+		} else {
+			delete(facets, facet)
+		}
+
+		if s, ok := sub.FacetsStats[facet]; ok {
+			stats[facet] = s
+		}
+
+		if !sub.ExhaustiveFacetsCount {
+			res.ExhaustiveFacetsCount = false
+		}
+	}
+
+	for facet, values := range state.ConjunctiveFacets {
+		addRefinedValues(facets, facet, values)
+	}
+	for facet, values := range state.DisjunctiveFacets {
+		addRefinedValues(facets, facet, values)
+	}
+
+	res.Facets = facets
+	res.FacetsStats = stats
+	return res
+}
+
+// addRefinedValues adds the refined `values` of `facet` which are missing from
+// the `facets` counts with a count of 0.
+func addRefinedValues(facets Map, facet string, values []string) {
+	if len(values) == 0 {
+		return
+	}
+
+	counts := make(map[string]interface{})
+	if existing, ok := facets[facet].(map[string]interface{}); ok {
+		for value, count := range existing {
+			counts[value] = count
+		}
+	}
+
+	for _, value := range values {
+		if _, ok := counts[value]; !ok {
+			counts[value] = 0.0
+		}
+	}
+
+	facets[facet] = counts
+}
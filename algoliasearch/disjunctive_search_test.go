@@ -0,0 +1,134 @@
+package algoliasearch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDisjunctiveQueries(t *testing.T) {
+	state := RefinementState{
+		ConjunctiveFacets: map[string][]string{
+			"category": {"phone"},
+		},
+		DisjunctiveFacets: map[string][]string{
+			"brand": {"apple", "-samsung"},
+			"color": nil,
+			"price": nil,
+		},
+		NumericRefinements: []NumericRefinement{
+			{Attribute: "price", Operator: ">=", Value: 100},
+			{Attribute: "rating", Operator: ">", Value: 3.5},
+		},
+	}
+
+	queries := disjunctiveQueries("products", "smart", Map{"hitsPerPage": 10}, state)
+	require.Len(t, queries, 3)
+
+	require.Equal(t, IndexedQuery{IndexName: "products", Params: Map{
+		"query":          "smart",
+		"hitsPerPage":    10,
+		"facets":         []string{"brand", "category", "color", "price"},
+		"facetFilters":   [][]string{{"category:phone"}, {"brand:apple", `brand:\-samsung`}},
+		"numericFilters": []interface{}{"price>=100", "rating>3.5"},
+	}}, queries[0])
+
+	// The query of the `brand` facet ignores the `brand` refinements.
+	require.Equal(t, []string{"brand"}, queries[1].Params["facets"])
+	require.Equal(t, [][]string{{"category:phone"}}, queries[1].Params["facetFilters"])
+	require.Equal(t, []interface{}{"price>=100", "rating>3.5"}, queries[1].Params["numericFilters"])
+	require.Equal(t, 0, queries[1].Params["hitsPerPage"])
+
+	// The query of the `price` facet ignores the `price` numeric refinement.
+	require.Equal(t, []string{"price"}, queries[2].Params["facets"])
+	require.Equal(t, [][]string{{"category:phone"}, {"brand:apple", `brand:\-samsung`}}, queries[2].Params["facetFilters"])
+	require.Equal(t, []interface{}{"rating>3.5"}, queries[2].Params["numericFilters"])
+
+	for _, q := range queries {
+		require.NoError(t, checkQuery(q.Params))
+	}
+}
+
+func TestMergeDisjunctiveResults(t *testing.T) {
+	state := RefinementState{
+		ConjunctiveFacets: map[string][]string{"category": {"phone"}},
+		DisjunctiveFacets: map[string][]string{"brand": {"apple", "nokia"}, "color": nil},
+		NumericRefinements: []NumericRefinement{
+			{Attribute: "price", Operator: "<", Value: 500},
+		},
+	}
+
+	main := MultipleQueryRes{QueryRes: QueryRes{
+		NbHits:                2,
+		ExhaustiveFacetsCount: true,
+		Facets: Map{
+			"brand":    map[string]interface{}{"apple": 2.0},
+			"category": map[string]interface{}{"phone": 2.0},
+			"color":    map[string]interface{}{"black": 1.0, "white": 1.0},
+		},
+		FacetsStats: Map{"price": Map{"min": 100.0, "max": 400.0}},
+	}}
+	brand := MultipleQueryRes{QueryRes: QueryRes{
+		ExhaustiveFacetsCount: false,
+		Facets: Map{
+			"brand": map[string]interface{}{"apple": 2.0, "samsung": 3.0},
+		},
+	}}
+
+	res := mergeDisjunctiveResults([]MultipleQueryRes{main, brand}, state)
+
+	require.Equal(t, 2, res.NbHits)
+	require.False(t, res.ExhaustiveFacetsCount)
+	require.Equal(t, Map{
+		"brand":    map[string]interface{}{"apple": 2.0, "samsung": 3.0, "nokia": 0.0},
+		"category": map[string]interface{}{"phone": 2.0},
+		"color":    map[string]interface{}{"black": 1.0, "white": 1.0},
+	}, res.Facets)
+	require.Equal(t, Map{"price": Map{"min": 100.0, "max": 400.0}}, res.FacetsStats)
+}
+
+func TestDisjunctiveSearch_invalid(t *testing.T) {
+	i := NewClient("appID", "apiKey").InitIndex("TestDisjunctiveSearch_invalid")
+
+	_, err := i.DisjunctiveSearch("", Map{"facetFilters": "brand:apple"}, RefinementState{})
+	require.Error(t, err)
+
+	_, err = i.DisjunctiveSearch("", nil, RefinementState{
+		NumericRefinements: []NumericRefinement{{Attribute: "price", Operator: "~", Value: 1}},
+	})
+	require.Error(t, err)
+}
+
+func TestDisjunctiveSearch(t *testing.T) {
+	t.Parallel()
+	_, i := initClientAndIndex(t, "TestDisjunctiveSearch")
+
+	t.Log("TestDisjunctiveSearch: Add the settings and the records")
+	{
+		res, err := i.SetSettings(Map{
+			"attributesForFaceting": []string{"brand", "category"},
+		})
+		require.NoError(t, err)
+		waitTask(t, i, res.TaskID)
+
+		batchRes, err := i.AddObjects([]Object{
+			{"brand": "apple", "category": "phone", "price": 800},
+			{"brand": "apple", "category": "laptop", "price": 1500},
+			{"brand": "samsung", "category": "phone", "price": 600},
+			{"brand": "nokia", "category": "phone", "price": 100},
+		})
+		require.NoError(t, err)
+		waitTask(t, i, batchRes.TaskID)
+	}
+
+	t.Log("TestDisjunctiveSearch: Search phones from apple or samsung")
+	{
+		res, err := i.DisjunctiveSearch("", nil, RefinementState{
+			ConjunctiveFacets: map[string][]string{"category": {"phone"}},
+			DisjunctiveFacets: map[string][]string{"brand": {"apple", "samsung"}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, 2, res.NbHits)
+		require.Equal(t, map[string]interface{}{"apple": 1.0, "samsung": 1.0, "nokia": 1.0}, res.Facets["brand"])
+	}
+}
@@ -9,7 +9,14 @@ var (
 	NoMoreHitsErr               error = errors.New("No more hits")
 	NoMoreSynonymsErr           error = errors.New("No more synonyms")
 	NoMoreRulesErr              error = errors.New("No more rules")
+	NoMoreUserIDsErr            error = errors.New("No more user IDs")
+	NoMoreIndexesErr            error = errors.New("No more indexes")
 	ExhaustionOfTryableHostsErr error = errors.New("All hosts have been contacted unsuccessfully")
+	WaitTaskTimeoutErr          error = errors.New("Task has not been published before the wait timeout")
+	NotAwaitableTaskErr         error = errors.New("Task cannot be waited for as the response does not originate from a Client")
+	NotEnoughABTestDataErr      error = errors.New("AB Test variants have not been searched enough to be compared")
+	NoSettingsSnapshotStoreErr  error = errors.New("No settings snapshot store has been set on the client")
+	SettingsSnapshotNotFoundErr error = errors.New("Settings snapshot not found")
 )
 
 // NetError is used internally to differente regular error from errors
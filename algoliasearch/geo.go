@@ -0,0 +1,56 @@
+package algoliasearch
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// HitGeoloc returns the locations found in the `_geoloc` attribute of the
+// `hit`, which holds either a single location or an array of locations.
+func HitGeoloc(hit Map) (points []GeoPoint, err error) {
+	geoloc, ok := hit["_geoloc"]
+	if !ok {
+		err = fmt.Errorf("hit has no `_geoloc`")
+		return
+	}
+
+	if _, isArray := geoloc.([]interface{}); isArray {
+		err = decodeInto(geoloc, &points, "_geoloc")
+		return
+	}
+
+	var point GeoPoint
+	if err = decodeInto(geoloc, &point, "_geoloc"); err == nil {
+		points = []GeoPoint{point}
+	}
+	return
+}
+
+// HitGeoDistance returns the distance, in meters, between the searched
+// location and the closest location of the `hit`, as found in its
+// `_rankingInfo.geoDistance`. The `getRankingInfo` parameter should be
+// enabled.
+func HitGeoDistance(hit Map) (meters int, err error) {
+	info, err := HitRankingInfo(hit)
+	meters = info.GeoDistance
+	return
+}
+
+// AutomaticRadiusMeters returns the radius, in meters, computed by the engine
+// for a geo search without any `aroundRadius`, as found in the
+// `automaticRadius` of the response.
+func (r QueryRes) AutomaticRadiusMeters() (meters int, err error) {
+	if r.AutomaticRadius == "" {
+		err = fmt.Errorf("no `automaticRadius` in the response")
+		return
+	}
+
+	f, err := strconv.ParseFloat(r.AutomaticRadius, 64)
+	if err != nil {
+		err = fmt.Errorf("cannot parse `automaticRadius` %q: %s", r.AutomaticRadius, err)
+		return
+	}
+
+	meters = int(f)
+	return
+}
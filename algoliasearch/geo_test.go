@@ -0,0 +1,78 @@
+package algoliasearch
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeMap_geo(t *testing.T) {
+	paris := GeoPoint{Lat: 48.8566, Lng: 2.3522}
+	london := GeoPoint{Lat: 51.5074, Lng: -0.1278}
+	berlin := GeoPoint{Lat: 52.52, Lng: 13.405}
+
+	for _, c := range []struct {
+		param    string
+		value    interface{}
+		expected string
+	}{
+		{"aroundLatLng", paris, "48.8566,2.3522"},
+		{"aroundRadius", NewRadius(1500), "1500"},
+		{"aroundRadius", RadiusAll, "all"},
+		{"insideBoundingBox", BoundingBox{paris, london}, "48.8566,2.3522,51.5074,-0.1278"},
+		{"insideBoundingBox", []BoundingBox{{paris, london}, {london, berlin}}, "[[48.8566,2.3522,51.5074,-0.1278],[51.5074,-0.1278,52.52,13.405]]"},
+		{"insidePolygon", Polygon{paris, london, berlin}, "48.8566,2.3522,51.5074,-0.1278,52.52,13.405"},
+		{"insidePolygon", []Polygon{{paris, london, berlin}}, "[[48.8566,2.3522,51.5074,-0.1278,52.52,13.405]]"},
+	} {
+		values, err := url.ParseQuery(encodeMap(Map{c.param: c.value}))
+		require.NoError(t, err)
+		require.Equal(t, c.expected, values.Get(c.param))
+	}
+}
+
+func TestRadius_Meters(t *testing.T) {
+	meters, ok := NewRadius(1000).Meters()
+	require.True(t, ok)
+	require.Equal(t, 1000, meters)
+
+	_, ok = RadiusAll.Meters()
+	require.False(t, ok)
+}
+
+func TestHitGeoloc(t *testing.T) {
+	points, err := HitGeoloc(Map{"_geoloc": map[string]interface{}{"lat": 48.8566, "lng": 2.3522}})
+	require.NoError(t, err)
+	require.Equal(t, []GeoPoint{{Lat: 48.8566, Lng: 2.3522}}, points)
+
+	points, err = HitGeoloc(Map{"_geoloc": []interface{}{
+		map[string]interface{}{"lat": 48.8566, "lng": 2.3522},
+		map[string]interface{}{"lat": 51.5074, "lng": -0.1278},
+	}})
+	require.NoError(t, err)
+	require.Equal(t, []GeoPoint{{Lat: 48.8566, Lng: 2.3522}, {Lat: 51.5074, Lng: -0.1278}}, points)
+
+	_, err = HitGeoloc(Map{"objectID": "1"})
+	require.Error(t, err)
+}
+
+func TestHitGeoDistance(t *testing.T) {
+	meters, err := HitGeoDistance(Map{"_rankingInfo": map[string]interface{}{"geoDistance": 1234.0}})
+	require.NoError(t, err)
+	require.Equal(t, 1234, meters)
+
+	_, err = HitGeoDistance(Map{})
+	require.Error(t, err)
+}
+
+func TestQueryRes_AutomaticRadiusMeters(t *testing.T) {
+	meters, err := QueryRes{AutomaticRadius: "7335"}.AutomaticRadiusMeters()
+	require.NoError(t, err)
+	require.Equal(t, 7335, meters)
+
+	_, err = QueryRes{}.AutomaticRadiusMeters()
+	require.Error(t, err)
+
+	_, err = QueryRes{AutomaticRadius: "far"}.AutomaticRadiusMeters()
+	require.Error(t, err)
+}
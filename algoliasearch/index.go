@@ -1,6 +1,7 @@
 package algoliasearch
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
@@ -30,6 +31,7 @@ func (i *index) Delete() (res DeleteTaskRes, err error) {
 func (i *index) DeleteWithRequestOptions(opts *RequestOptions) (res DeleteTaskRes, err error) {
 	path := i.route
 	err = i.client.request(&res, "DELETE", path, nil, write, opts)
+	res.bind(i.client, i.name, opts)
 	return
 }
 
@@ -40,6 +42,7 @@ func (i *index) Clear() (res UpdateTaskRes, err error) {
 func (i *index) ClearWithRequestOptions(opts *RequestOptions) (res UpdateTaskRes, err error) {
 	path := i.route + "/clear"
 	err = i.client.request(&res, "POST", path, nil, write, opts)
+	res.bind(i.client, i.name, opts)
 	return
 }
 
@@ -118,6 +121,7 @@ func (i *index) DeleteObjectWithRequestOptions(objectID string, opts *RequestOpt
 
 	path := i.route + "/" + url.QueryEscape(objectID)
 	err = i.client.request(&res, "DELETE", path, nil, write, opts)
+	res.bind(i.client, i.name, opts)
 	return
 }
 
@@ -128,7 +132,6 @@ func (i *index) GetSettings() (settings Settings, err error) {
 func (i *index) GetSettingsWithRequestOptions(opts *RequestOptions) (settings Settings, err error) {
 	path := i.route + "/settings?getVersion=2"
 	err = i.client.request(&settings, "GET", path, nil, read, opts)
-	settings.clean()
 	return
 }
 
@@ -137,7 +140,11 @@ func (i *index) SetSettings(settings Map) (res UpdateTaskRes, err error) {
 }
 
 func (i *index) SetSettingsWithRequestOptions(settings Map, opts *RequestOptions) (res UpdateTaskRes, err error) {
-	if err = checkSettings(settings); err != nil {
+	if err = i.client.checkSettings(settings); err != nil {
+		return
+	}
+
+	if err = i.snapshotSettings(opts); err != nil {
 		return
 	}
 
@@ -150,9 +157,20 @@ func (i *index) SetSettingsWithRequestOptions(settings Map, opts *RequestOptions
 
 	path := i.route + "/settings?forwardToReplicas=" + fmt.Sprintf("%t", forwardToReplicas)
 	err = i.client.request(&res, "PUT", path, settings, write, opts)
+	res.bind(i.client, i.name, opts)
 	return
 }
 
+func (i *index) SetTypedSettings(settings Settings, forwardToReplicas bool) (res UpdateTaskRes, err error) {
+	return i.SetTypedSettingsWithRequestOptions(settings, forwardToReplicas, nil)
+}
+
+func (i *index) SetTypedSettingsWithRequestOptions(settings Settings, forwardToReplicas bool, opts *RequestOptions) (res UpdateTaskRes, err error) {
+	m := settings.ToMap()
+	m["forwardToReplicas"] = forwardToReplicas
+	return i.SetSettingsWithRequestOptions(m, opts)
+}
+
 func (i *index) WaitTask(taskID int) error {
 	return i.WaitTaskWithRequestOptions(taskID, nil)
 }
@@ -248,6 +266,7 @@ func (i *index) AddObject(object Object) (res CreateObjectRes, err error) {
 func (i *index) AddObjectWithRequestOptions(object Object, opts *RequestOptions) (res CreateObjectRes, err error) {
 	path := i.route
 	err = i.client.request(&res, "POST", path, object, write, opts)
+	res.bind(i.client, i.name, opts)
 	return
 }
 
@@ -263,6 +282,7 @@ func (i *index) UpdateObjectWithRequestOptions(object Object, opts *RequestOptio
 
 	path := i.route + "/" + url.QueryEscape(objectID)
 	err = i.client.request(&res, "PUT", path, object, write, opts)
+	res.bind(i.client, i.name, opts)
 	return
 }
 
@@ -277,6 +297,7 @@ func (i *index) partialUpdateObject(object Object, createIfNotExists bool, opts
 		path += "?createIfNotExists=false"
 	}
 	err = i.client.request(&res, "POST", path, object, write, opts)
+	res.bind(i.client, i.name, opts)
 	return
 }
 
@@ -382,6 +403,7 @@ func (i *index) BatchWithRequestOptions(operations []BatchOperation, opts *Reque
 
 	path := i.route + "/batch"
 	err = i.client.request(&res, "POST", path, body, write, opts)
+	res.bind(i.client, i.name, opts)
 	return
 }
 
@@ -422,6 +444,7 @@ func (i *index) operation(dst, op string, scopes []string, opts *RequestOptions)
 
 	path := i.route + "/operation"
 	err = i.client.request(&res, "POST", path, o, write, opts)
+	res.bind(i.client, i.name, opts)
 	return
 }
 
@@ -486,6 +509,7 @@ func (i *index) SaveSynonymWithRequestOptions(synonym Synonym, forwardToReplicas
 
 	path := i.route + "/synonyms/" + url.QueryEscape(synonym.ObjectID) + "?" + encodeMap(params)
 	err = i.client.request(&res, "PUT", path, synonym, write, opts)
+	res.bind(i.client, i.name, opts)
 	return
 }
 
@@ -500,6 +524,7 @@ func (i *index) DeleteSynonymWithRequestOptions(objectID string, forwardToReplic
 
 	path := i.route + "/synonyms/" + url.QueryEscape(objectID) + "?" + encodeMap(params)
 	err = i.client.request(&res, "DELETE", path, nil, write, opts)
+	res.bind(i.client, i.name, opts)
 	return
 }
 
@@ -514,6 +539,7 @@ func (i *index) ClearSynonymsWithRequestOptions(forwardToReplicas bool, opts *Re
 
 	path := i.route + "/synonyms/clear?" + encodeMap(params)
 	err = i.client.request(&res, "POST", path, nil, write, opts)
+	res.bind(i.client, i.name, opts)
 	return
 }
 
@@ -529,6 +555,7 @@ func (i *index) BatchSynonymsWithRequestOptions(synonyms []Synonym, replaceExist
 
 	path := i.route + "/synonyms/batch?" + encodeMap(params)
 	err = i.client.request(&res, "POST", path, synonyms, write, opts)
+	res.bind(i.client, i.name, opts)
 	return
 }
 
@@ -538,7 +565,7 @@ func (i *index) Browse(params Map, cursor string) (res BrowseRes, err error) {
 
 func (i *index) BrowseWithRequestOptions(params Map, cursor string, opts *RequestOptions) (res BrowseRes, err error) {
 	copy := duplicateMap(params)
-	if err = checkQuery(copy); err != nil {
+	if err = i.client.checkQuery(copy); err != nil {
 		return
 	}
 
@@ -560,11 +587,24 @@ func (i *index) BrowseAll(params Map) (it IndexIterator, err error) {
 }
 
 func (i *index) BrowseAllWithRequestOptions(params Map, opts *RequestOptions) (it IndexIterator, err error) {
-	if err = checkQuery(params); err != nil {
+	if err = i.client.checkQuery(params); err != nil {
 		return
 	}
 
-	it, err = newIndexIterator(i, params, opts)
+	it, err = newIndexIterator(i, params, BrowseCheckpoint{}, nil, opts)
+	return
+}
+
+func (i *index) ResumeBrowseAll(params Map, checkpoint BrowseCheckpoint, checkpointer Checkpointer) (it IndexIterator, err error) {
+	return i.ResumeBrowseAllWithRequestOptions(params, checkpoint, checkpointer, nil)
+}
+
+func (i *index) ResumeBrowseAllWithRequestOptions(params Map, checkpoint BrowseCheckpoint, checkpointer Checkpointer, opts *RequestOptions) (it IndexIterator, err error) {
+	if err = i.client.checkQuery(params); err != nil {
+		return
+	}
+
+	it, err = newIndexIterator(i, params, checkpoint, checkpointer, opts)
 	return
 }
 
@@ -576,7 +616,7 @@ func (i *index) SearchWithRequestOptions(query string, params Map, opts *Request
 	copy := duplicateMap(params)
 	copy["query"] = query
 
-	if err = checkQuery(copy); err != nil {
+	if err = i.client.checkQuery(copy); err != nil {
 		return
 	}
 
@@ -585,7 +625,7 @@ func (i *index) SearchWithRequestOptions(query string, params Map, opts *Request
 	}
 
 	path := i.route + "/query"
-	err = i.client.request(&res, "POST", path, req, search, opts)
+	err = i.client.cachedRequest(&res, "POST", path, req, search, opts)
 	return
 }
 
@@ -594,7 +634,7 @@ func (i *index) DeleteBy(params Map) (res UpdateTaskRes, err error) {
 }
 
 func (i *index) DeleteByWithRequestOptions(params Map, opts *RequestOptions) (res UpdateTaskRes, err error) {
-	if err = checkQuery(params); err != nil {
+	if err = i.client.checkQuery(params); err != nil {
 		return
 	}
 
@@ -604,6 +644,7 @@ func (i *index) DeleteByWithRequestOptions(params Map, opts *RequestOptions) (re
 
 	path := i.route + "/deleteByQuery"
 	err = i.client.request(&res, "POST", path, req, write, opts)
+	res.bind(i.client, i.name, opts)
 	return
 }
 
@@ -612,6 +653,20 @@ func (i *index) DeleteByQuery(query string, params Map) (err error) {
 }
 
 func (i *index) DeleteByQueryWithRequestOptions(query string, params Map, opts *RequestOptions) (err error) {
+	_, err = i.DeleteByQueryInBatchesWithRequestOptions(context.Background(), query, params, DeleteByQueryOptions{}, opts)
+	return
+}
+
+func (i *index) DeleteByQueryInBatches(ctx context.Context, query string, params Map, options DeleteByQueryOptions) (res DeleteByQueryRes, err error) {
+	return i.DeleteByQueryInBatchesWithRequestOptions(ctx, query, params, options, nil)
+}
+
+func (i *index) DeleteByQueryInBatchesWithRequestOptions(ctx context.Context, query string, params Map, options DeleteByQueryOptions, opts *RequestOptions) (res DeleteByQueryRes, err error) {
+	batchSize := options.BatchSize
+	if batchSize <= 0 {
+		batchSize = 1000
+	}
+
 	copy := duplicateMap(params)
 	copy["attributesToRetrieve"] = []string{"objectID"}
 	copy["hitsPerPage"] = 1000
@@ -619,37 +674,88 @@ func (i *index) DeleteByQueryWithRequestOptions(query string, params Map, opts *
 	copy["distinct"] = 0
 
 	var browseRes BrowseRes
-	var batchRes BatchRes
 	var objectIDs []string
 	var cursor string
 
+	// reportProgress calls the Progress callback, if any, with a copy of the
+	// progress so far which is not affected by the next batches.
+	reportProgress := func() {
+		if options.Progress != nil {
+			progress := res
+			progress.TaskIDs = append([]int(nil), res.TaskIDs...)
+			options.Progress(progress)
+		}
+	}
+
+	// deleteBatch sends the deletion of the pending objectIDs, if any, without
+	// waiting for the task to complete.
+	deleteBatch := func() error {
+		if len(objectIDs) == 0 {
+			return nil
+		}
+		batchRes, err := i.DeleteObjectsWithRequestOptions(objectIDs, opts)
+		if err != nil {
+			return err
+		}
+		res.NbDeleted += len(objectIDs)
+		res.TaskIDs = append(res.TaskIDs, batchRes.TaskID)
+		objectIDs = objectIDs[:0]
+		reportProgress()
+		return nil
+	}
+
 	for {
-		// Start browsing the content by cursor
+		if err = ctx.Err(); err != nil {
+			return
+		}
+
+		// Browse the next page of matching records by cursor
 		if browseRes, err = i.BrowseWithRequestOptions(copy, cursor, opts); err != nil {
 			return
 		}
 
-		// Collect all objectIDs
 		for _, hit := range browseRes.Hits {
-			objectIDs = append(objectIDs, hit["objectID"].(string))
+			var objectID string
+			if objectID, err = Object(hit).ObjectID(); err != nil {
+				return
+			}
+			res.NbMatched++
+
+			if options.DryRun {
+				continue
+			}
+
+			objectIDs = append(objectIDs, objectID)
+			if len(objectIDs) >= batchSize {
+				if err = deleteBatch(); err != nil {
+					return
+				}
+			}
 		}
 
-		// Set the new cursor from response
-		cursor = browseRes.Cursor
+		// As nothing is deleted during a dry run, the progress is reported
+		// after each browsed page instead.
+		if options.DryRun {
+			reportProgress()
+		}
 
-		// Break if there's no more matching records
-		if cursor == "" {
+		// Set the new cursor from response and stop if there's no more
+		// matching records
+		if cursor = browseRes.Cursor; cursor == "" {
 			break
 		}
 	}
 
-	// Delete all the objects
-	if batchRes, err = i.DeleteObjectsWithRequestOptions(objectIDs, opts); err != nil {
+	if err = deleteBatch(); err != nil {
 		return
 	}
 
-	// Wait until DeleteObjects completion
-	err = i.WaitTaskWithRequestOptions(batchRes.TaskID, opts)
+	// As the tasks of an index are processed sequentially, waiting for the
+	// last deletion batch is enough to know that all of them are completed.
+	if len(res.TaskIDs) > 0 {
+		err = i.client.waitTask(ctx, i.name, res.TaskIDs[len(res.TaskIDs)-1], opts)
+	}
+
 	return
 }
 
@@ -663,7 +769,7 @@ func (i *index) SearchForFacetValues(facet, query string, params Map) (res Searc
 
 func (i *index) SearchForFacetValuesWithRequestOptions(facet, query string, params Map, opts *RequestOptions) (res SearchFacetRes, err error) {
 	copy := duplicateMap(params)
-	if err = checkQuery(copy); err != nil {
+	if err = i.client.checkQuery(copy); err != nil {
 		return
 	}
 
@@ -674,7 +780,7 @@ func (i *index) SearchForFacetValuesWithRequestOptions(facet, query string, para
 	}
 
 	path := i.route + "/facets/" + facet + "/query"
-	err = i.client.request(&res, "POST", path, req, search, opts)
+	err = i.client.cachedRequest(&res, "POST", path, req, search, opts)
 	return
 }
 
@@ -692,6 +798,7 @@ func (i *index) SaveRuleWithRequestOptions(rule Rule, forwardToReplicas bool, op
 	params := Map{"forwardToReplicas": forwardToReplicas}
 	path := i.route + "/rules/" + rule.ObjectID + "?" + encodeMap(params)
 	err = i.client.request(&res, "PUT", path, rule, write, opts)
+	res.bind(i.client, i.name, opts)
 	return
 }
 
@@ -704,6 +811,13 @@ func (i *index) BatchRulesWithRequestOptions(rules []Rule, forwardToReplicas, cl
 		return
 	}
 
+	return i.batchRules(rules, forwardToReplicas, clearExistingRules, opts)
+}
+
+// batchRules saves the given `rules` without type-checking them first. This is
+// needed for Rules read back from the API, whose consequence parameters are
+// decoded as generic JSON values.
+func (i *index) batchRules(rules []Rule, forwardToReplicas, clearExistingRules bool, opts *RequestOptions) (res BatchRulesRes, err error) {
 	for i, _ := range rules {
 		rules[i].enableImplicitly()
 	}
@@ -714,6 +828,7 @@ func (i *index) BatchRulesWithRequestOptions(rules []Rule, forwardToReplicas, cl
 	}
 	path := i.route + "/rules/batch?" + encodeMap(params)
 	err = i.client.request(&res, "POST", path, rules, write, opts)
+	res.bind(i.client, i.name, opts)
 	return
 }
 
@@ -735,6 +850,7 @@ func (i *index) DeleteRuleWithRequestOptions(objectID string, forwardToReplicas
 	params := Map{"forwardToReplicas": forwardToReplicas}
 	path := i.route + "/rules/" + objectID + "?" + encodeMap(params)
 	err = i.client.request(&res, "DELETE", path, nil, write, opts)
+	res.bind(i.client, i.name, opts)
 	return
 }
 
@@ -746,6 +862,7 @@ func (i *index) ClearRulesWithRequestOptions(forwardToReplicas bool, opts *Reque
 	params := Map{"forwardToReplicas": forwardToReplicas}
 	path := i.route + "/rules/clear?" + encodeMap(params)
 	err = i.client.request(&res, "POST", path, nil, write, opts)
+	res.bind(i.client, i.name, opts)
 	return
 }
 
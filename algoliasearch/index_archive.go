@@ -0,0 +1,244 @@
+package algoliasearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// archiveBatchSize is the number of records, Rules or synonyms which are
+// retrieved or sent at once while exporting or importing an index.
+const archiveBatchSize = 1000
+
+func (i *index) Export(w io.Writer) (res IndexArchiveRes, err error) {
+	return i.ExportWithRequestOptions(w, nil)
+}
+
+func (i *index) ExportWithRequestOptions(w io.Writer, opts *RequestOptions) (res IndexArchiveRes, err error) {
+	metadata := IndexArchiveMetadata{
+		Format:     IndexArchiveFormat,
+		Version:    IndexArchiveVersion,
+		IndexName:  i.name,
+		ExportedAt: time.Now().UTC(),
+	}
+
+	if metadata.Settings, err = i.GetSettingsWithRequestOptions(opts); err != nil {
+		return
+	}
+
+	if metadata.Rules, err = i.allRules(opts); err != nil {
+		return
+	}
+
+	if metadata.Synonyms, err = i.allSynonyms(opts); err != nil {
+		return
+	}
+
+	enc := json.NewEncoder(w)
+
+	if err = enc.Encode(metadata); err != nil {
+		err = fmt.Errorf("cannot write archive metadata: %s", err)
+		return
+	}
+	res.NbRules = len(metadata.Rules)
+	res.NbSynonyms = len(metadata.Synonyms)
+
+	it, err := i.BrowseAllWithRequestOptions(nil, opts)
+	for err == nil {
+		var hit Map
+		if hit, err = it.Next(); err != nil {
+			break
+		}
+
+		removeHitMetadata(hit)
+		if err = enc.Encode(hit); err != nil {
+			err = fmt.Errorf("cannot write record to archive: %s", err)
+			return
+		}
+		res.NbRecords++
+	}
+
+	if err == NoMoreHitsErr {
+		err = nil
+	}
+
+	return
+}
+
+func (i *index) Import(r io.Reader) (res IndexArchiveRes, err error) {
+	return i.ImportWithRequestOptions(r, nil)
+}
+
+func (i *index) ImportWithRequestOptions(r io.Reader, opts *RequestOptions) (res IndexArchiveRes, err error) {
+	dec := json.NewDecoder(r)
+
+	var metadata IndexArchiveMetadata
+	if err = dec.Decode(&metadata); err != nil {
+		err = fmt.Errorf("cannot read archive metadata: %s", err)
+		return
+	}
+
+	// Numbers of the records are kept as json.Number so that large integers
+	// are restored without any loss of precision. This is only enabled once
+	// the metadata is decoded, as Settings expect regular float64 values.
+	dec.UseNumber()
+
+	if metadata.Format != IndexArchiveFormat || metadata.Version != IndexArchiveVersion {
+		err = fmt.Errorf("unsupported archive format %q (version %d)", metadata.Format, metadata.Version)
+		return
+	}
+
+	// As the tasks of an index are processed sequentially, only the last task
+	// needs to be waited for.
+	var lastTaskID int
+
+	// The replicas of the exported index are not restored: attaching them to
+	// this index would detach them from their actual primary, or create them
+	// if they do not exist.
+	metadata.Settings.Replicas = nil
+	metadata.Settings.Slaves = nil
+
+	settingsRes, err := i.SetTypedSettingsWithRequestOptions(metadata.Settings, false, opts)
+	if err != nil {
+		return
+	}
+	lastTaskID = settingsRes.TaskID
+
+	var objects []Object
+	for {
+		var object Object
+		if err = dec.Decode(&object); err == io.EOF {
+			break
+		} else if err != nil {
+			err = fmt.Errorf("cannot read record %d from archive: %s", res.NbRecords+len(objects)+1, err)
+			return
+		}
+
+		objects = append(objects, object)
+		if len(objects) == archiveBatchSize {
+			if lastTaskID, err = i.importRecords(objects, opts); err != nil {
+				return
+			}
+			res.NbRecords += len(objects)
+			objects = objects[:0]
+		}
+	}
+
+	if len(objects) > 0 {
+		if lastTaskID, err = i.importRecords(objects, opts); err != nil {
+			return
+		}
+		res.NbRecords += len(objects)
+	}
+
+	// Rules which were explicitly disabled are kept disabled instead of being
+	// implicitly enabled when saved.
+	for j := range metadata.Rules {
+		if !metadata.Rules[j].Enabled {
+			metadata.Rules[j].Disable()
+		}
+	}
+
+	if lastTaskID, err = i.replaceRules(metadata.Rules, opts); err != nil {
+		return
+	}
+	res.NbRules = len(metadata.Rules)
+
+	if lastTaskID, err = i.replaceSynonyms(metadata.Synonyms, opts); err != nil {
+		return
+	}
+	res.NbSynonyms = len(metadata.Synonyms)
+
+	err = i.WaitTaskWithRequestOptions(lastTaskID, opts)
+	return
+}
+
+// importRecords saves the given `objects` in a single batch and returns the
+// ID of the corresponding task.
+func (i *index) importRecords(objects []Object, opts *RequestOptions) (taskID int, err error) {
+	res, err := i.UpdateObjectsWithRequestOptions(objects, opts)
+	return res.TaskID, err
+}
+
+// replaceRules replaces all the Rules of the index with the given `rules` and
+// returns the ID of the corresponding task. As the batch endpoint expects an
+// array, the Rules are cleared instead if there is none.
+func (i *index) replaceRules(rules []Rule, opts *RequestOptions) (taskID int, err error) {
+	if len(rules) == 0 {
+		var res ClearRulesRes
+		res, err = i.ClearRulesWithRequestOptions(false, opts)
+		return res.TaskID, err
+	}
+
+	res, err := i.batchRules(rules, false, true, opts)
+	return res.TaskID, err
+}
+
+// replaceSynonyms replaces all the synonyms of the index with the given
+// `synonyms` and returns the ID of the corresponding task. As the batch
+// endpoint expects an array, the synonyms are cleared instead if there is
+// none.
+func (i *index) replaceSynonyms(synonyms []Synonym, opts *RequestOptions) (taskID int, err error) {
+	if len(synonyms) == 0 {
+		var res UpdateTaskRes
+		res, err = i.ClearSynonymsWithRequestOptions(false, opts)
+		return res.TaskID, err
+	}
+
+	res, err := i.BatchSynonymsWithRequestOptions(synonyms, true, false, opts)
+	return res.TaskID, err
+}
+
+// allRules retrieves all the Rules of the index, page by page.
+func (i *index) allRules(opts *RequestOptions) (rules []Rule, err error) {
+	it := NewRuleIteratorWithOptions(context.Background(), i, IteratorOptions{
+		HitsPerPage:    archiveBatchSize,
+		RequestOptions: opts,
+	})
+
+	for {
+		var rule *Rule
+		if rule, err = it.Next(); err == NoMoreRulesErr {
+			err = nil
+			return
+		} else if err != nil {
+			return
+		}
+		rules = append(rules, *rule)
+	}
+}
+
+// allSynonyms retrieves all the synonyms of the index, page by page.
+func (i *index) allSynonyms(opts *RequestOptions) (synonyms []Synonym, err error) {
+	it := NewSynonymIteratorWithOptions(context.Background(), i, IteratorOptions{
+		HitsPerPage:    archiveBatchSize,
+		RequestOptions: opts,
+	})
+
+	for {
+		var synonym *Synonym
+		if synonym, err = it.Next(); err == NoMoreSynonymsErr {
+			err = nil
+			return
+		} else if err != nil {
+			return
+		}
+		synonyms = append(synonyms, *synonym)
+	}
+}
+
+// removeHitMetadata removes from `hit` the attributes computed by the engine
+// at query time, such as `_highlightResult` or `_rankingInfo`, which are not
+// part of the record itself.
+func removeHitMetadata(hit Map) {
+	for _, k := range []string{
+		"_distinctSeqID",
+		"_highlightResult",
+		"_rankingInfo",
+		"_snippetResult",
+	} {
+		delete(hit, k)
+	}
+}
@@ -0,0 +1,148 @@
+package algoliasearch
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestImport_invalidArchive(t *testing.T) {
+	i := NewClient("appID", "apiKey").InitIndex("TestImport_invalidArchive")
+
+	for _, archive := range []string{
+		``,
+		`not json`,
+		`{"format":"something-else","version":1}`,
+		`{"format":"algolia-index-archive","version":42}`,
+	} {
+		_, err := i.Import(strings.NewReader(archive))
+		require.Error(t, err, "should not import the following archive: %s", archive)
+	}
+}
+
+func TestImport_replicasAreNotRestored(t *testing.T) {
+	rt := &routingRoundTripper{responses: map[string]string{
+		"PUT /1/indexes/other/settings":        `{"taskID": 1}`,
+		"POST /1/indexes/other/rules/clear":    `{"taskID": 2}`,
+		"POST /1/indexes/other/synonyms/clear": `{"taskID": 3}`,
+		"GET /1/indexes/other/task/3":          `{"status": "published"}`,
+	}}
+	c := NewClient("appID", "apiKey")
+	c.SetHTTPClient(&http.Client{Transport: rt})
+
+	archive := `{"format":"algolia-index-archive","version":1,"indexName":"products",` +
+		`"settings":{"hitsPerPage":10,"replicas":["products_price_asc"],"slaves":["products_old"]}}`
+	_, err := c.InitIndex("other").Import(strings.NewReader(archive))
+	require.NoError(t, err)
+
+	require.Equal(t, "PUT /1/indexes/other/settings", rt.requests[0])
+	var settings Map
+	require.NoError(t, json.Unmarshal([]byte(rt.bodies[0]), &settings))
+	require.Equal(t, Map{"hitsPerPage": 10.0}, settings)
+}
+
+func TestExportImport_noRulesNorSynonyms(t *testing.T) {
+	rt := &routingRoundTripper{responses: map[string]string{
+		"GET /1/indexes/products/settings":         `{"hitsPerPage": 10}`,
+		"POST /1/indexes/products/rules/search":    `{"hits": [], "nbHits": 0, "page": 0, "nbPages": 0}`,
+		"POST /1/indexes/products/synonyms/search": `{"hits": [], "nbHits": 0}`,
+		"POST /1/indexes/products/browse":          `{"hits": []}`,
+		"PUT /1/indexes/other/settings":            `{"taskID": 1}`,
+		"POST /1/indexes/other/rules/clear":        `{"taskID": 2}`,
+		"POST /1/indexes/other/synonyms/clear":     `{"taskID": 3}`,
+		"GET /1/indexes/other/task/3":              `{"status": "published"}`,
+	}}
+	c := NewClient("appID", "apiKey")
+	c.SetHTTPClient(&http.Client{Transport: rt})
+
+	var archive bytes.Buffer
+	res, err := c.InitIndex("products").Export(&archive)
+	require.NoError(t, err)
+	require.Equal(t, IndexArchiveRes{}, res)
+
+	rt.requests, rt.bodies = nil, nil
+	res, err = c.InitIndex("other").Import(&archive)
+	require.NoError(t, err)
+	require.Equal(t, IndexArchiveRes{}, res)
+	require.Equal(t, []string{
+		"PUT /1/indexes/other/settings",
+		"POST /1/indexes/other/rules/clear",
+		"POST /1/indexes/other/synonyms/clear",
+		"GET /1/indexes/other/task/3",
+	}, rt.requests)
+	require.JSONEq(t, `{"hitsPerPage": 10}`, rt.bodies[0])
+	require.Empty(t, rt.bodies[1])
+	require.Empty(t, rt.bodies[2])
+}
+
+func TestRemoveHitMetadata(t *testing.T) {
+	hit := Map{
+		"objectID":         "one",
+		"_geoloc":          Map{"lat": 1.0, "lng": 2.0},
+		"_highlightResult": Map{},
+		"_snippetResult":   Map{},
+		"_rankingInfo":     Map{},
+		"_distinctSeqID":   1,
+	}
+
+	removeHitMetadata(hit)
+	require.Equal(t, Map{"objectID": "one", "_geoloc": Map{"lat": 1.0, "lng": 2.0}}, hit)
+}
+
+func TestExportImport(t *testing.T) {
+	t.Parallel()
+	c, i := initClientAndIndex(t, "TestExportImport")
+	synonyms := addObjectsAndSynonyms(t, i, "TestExportImport")
+	rules := addRules(t, i, "TestExportImport")
+
+	t.Log("TestExportImport: Export the index")
+	var archive bytes.Buffer
+	exportRes, err := i.Export(&archive)
+	require.NoError(t, err)
+	require.Equal(t, 9, exportRes.NbRecords)
+	require.Equal(t, len(rules), exportRes.NbRules)
+	require.Equal(t, len(synonyms), exportRes.NbSynonyms)
+
+	t.Log("TestExportImport: Check the archive format")
+	{
+		lines := strings.Split(strings.TrimSpace(archive.String()), "\n")
+		require.Len(t, lines, 1+exportRes.NbRecords)
+
+		var metadata IndexArchiveMetadata
+		require.NoError(t, json.Unmarshal([]byte(lines[0]), &metadata))
+		require.Equal(t, IndexArchiveFormat, metadata.Format)
+		require.Equal(t, "TestExportImport", metadata.IndexName)
+	}
+
+	t.Log("TestExportImport: Import the archive into a new index")
+	restored := initIndex(t, c, "TestExportImport_restored")
+	defer restored.Delete()
+	importRes, err := restored.Import(&archive)
+	require.NoError(t, err)
+	require.Equal(t, exportRes, importRes)
+
+	t.Log("TestExportImport: Compare both indices")
+	{
+		settings, err := i.GetSettings()
+		require.NoError(t, err)
+		restoredSettings, err := restored.GetSettings()
+		require.NoError(t, err)
+		settingsAreEqual(t, settings, restoredSettings)
+
+		restoredSynonyms, err := restored.SearchSynonyms("", nil, 0, 1000)
+		require.NoError(t, err)
+		require.True(t, synonymSlicesAreEqual(synonyms, restoredSynonyms))
+
+		restoredRules, err := restored.SearchRules(Map{"query": ""})
+		require.NoError(t, err)
+		require.True(t, ruleSlicesAreEqual(rules, restoredRules.Hits))
+
+		res, err := restored.Search("", nil)
+		require.NoError(t, err)
+		require.Equal(t, 9, res.NbHits)
+	}
+}
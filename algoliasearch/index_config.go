@@ -0,0 +1,510 @@
+package algoliasearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v2"
+)
+
+// LoadIndexConfigs reads the configuration of all the indices found in the
+// directory `dir`. Each sub-directory holds the configuration of the index
+// it is named after, split into the optional `settings`, `rules` and
+// `synonyms` files. The settings file holds a Settings object while the two
+// other ones hold an array of Rules and of synonyms respectively, as they are
+// encoded in JSON by this package:
+//
+//	config/
+//	  products/
+//	    settings.json
+//	    rules.json
+//	  products_price_asc/
+//	    settings.yaml
+//
+// The files can be written in JSON (`.json`) or YAML (`.yaml` or `.yml`), the
+// YAML ones holding the same structure as the JSON ones. Other formats are
+// supported through the `Converters` of `options`.
+//
+// All the configurations are validated before being returned. As no Client
+// is involved, the settings are only checked as with LenientValidation: the
+// ValidationPolicy of the Client applies once the planned settings are sent
+// by `ApplyIndexConfigPlan`.
+func LoadIndexConfigs(dir string, options LoadIndexConfigOptions) (configs []IndexConfig, err error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		err = fmt.Errorf("cannot read configuration directory: %s", err)
+		return
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		var config IndexConfig
+		if config, err = loadIndexConfig(filepath.Join(dir, entry.Name()), entry.Name(), options); err != nil {
+			return
+		}
+		configs = append(configs, config)
+	}
+
+	return
+}
+
+// loadIndexConfig reads and validates the configuration files of the index
+// `indexName` from the directory `dir`.
+func loadIndexConfig(dir, indexName string, options LoadIndexConfigOptions) (config IndexConfig, err error) {
+	config.IndexName = indexName
+
+	data, found, err := readIndexConfigFile(dir, IndexConfigSettingsFile, options)
+	if err != nil {
+		return
+	}
+	if found {
+		config.Settings = new(Settings)
+		if err = json.Unmarshal(data, config.Settings); err != nil {
+			err = fmt.Errorf("index %s: cannot decode settings: %s", indexName, err)
+			return
+		}
+		if err = checkSettings(config.Settings.ToMap()); err != nil {
+			err = fmt.Errorf("index %s: invalid settings: %s", indexName, err)
+			return
+		}
+	}
+
+	if data, found, err = readIndexConfigFile(dir, IndexConfigRulesFile, options); err != nil {
+		return
+	}
+	if found {
+		if config.Rules, err = decodeConfigRules(data); err != nil {
+			err = fmt.Errorf("index %s: cannot decode rules: %s", indexName, err)
+			return
+		}
+		if err = checkRules(config.Rules); err != nil {
+			err = fmt.Errorf("index %s: invalid rules: %s", indexName, err)
+			return
+		}
+	}
+
+	if data, found, err = readIndexConfigFile(dir, IndexConfigSynonymsFile, options); err != nil {
+		return
+	}
+	if found {
+		config.Synonyms = []Synonym{}
+		if err = json.Unmarshal(data, &config.Synonyms); err != nil {
+			err = fmt.Errorf("index %s: cannot decode synonyms: %s", indexName, err)
+			return
+		}
+		if err = checkSynonyms(config.Synonyms); err != nil {
+			err = fmt.Errorf("index %s: invalid synonyms: %s", indexName, err)
+			return
+		}
+	}
+
+	return
+}
+
+// readIndexConfigFile returns the content, converted to JSON, of the file
+// named `name` in `dir` with any of the supported extensions. `found` is
+// false if there is no such file.
+func readIndexConfigFile(dir, name string, options LoadIndexConfigOptions) (data []byte, found bool, err error) {
+	converters := make(map[string]func(data []byte) ([]byte, error))
+	for ext, convert := range defaultIndexConfigConverters {
+		converters[ext] = convert
+	}
+	for ext, convert := range options.Converters {
+		converters[ext] = convert
+	}
+
+	extensions := []string{".json"}
+	for ext := range converters {
+		if ext != ".json" {
+			extensions = append(extensions, ext)
+		}
+	}
+	sort.Strings(extensions[1:])
+
+	var path string
+	for _, ext := range extensions {
+		candidate := filepath.Join(dir, name+ext)
+		if _, statErr := os.Stat(candidate); statErr != nil {
+			continue
+		}
+		if found {
+			err = fmt.Errorf("both %s and %s exist", path, candidate)
+			return
+		}
+		path, found = candidate, true
+	}
+
+	if !found {
+		return
+	}
+
+	if data, err = ioutil.ReadFile(path); err != nil {
+		err = fmt.Errorf("cannot read %s: %s", path, err)
+		return
+	}
+
+	if convert, ok := converters[filepath.Ext(path)]; ok {
+		if data, err = convert(data); err != nil {
+			err = fmt.Errorf("cannot convert %s to JSON: %s", path, err)
+		}
+	}
+
+	return
+}
+
+// defaultIndexConfigConverters are the converters of the configuration file
+// formats, other than JSON, which are supported natively.
+var defaultIndexConfigConverters = map[string]func(data []byte) ([]byte, error){
+	".yaml": yamlToJSON,
+	".yml":  yamlToJSON,
+}
+
+// yamlToJSON converts the YAML document `data` to JSON.
+func yamlToJSON(data []byte) ([]byte, error) {
+	var v interface{}
+	if err := yaml.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(jsonCompatibleYAML(v))
+}
+
+// jsonCompatibleYAML converts the mappings decoded from YAML, whose keys may
+// be of any type, to maps with string keys so that they can be encoded in
+// JSON.
+func jsonCompatibleYAML(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			m[fmt.Sprint(k)] = jsonCompatibleYAML(e)
+		}
+		return m
+	case []interface{}:
+		for i, e := range v {
+			v[i] = jsonCompatibleYAML(e)
+		}
+		return v
+	}
+	return v
+}
+
+// decodeConfigRules decodes the JSON array of Rules `data`. Rules are enabled
+// unless they explicitly set `enabled` to false, and the parameters of their
+// consequence are converted to the types expected by `checkRules`.
+func decodeConfigRules(data []byte) (rules []Rule, err error) {
+	var raws []json.RawMessage
+	if err = json.Unmarshal(data, &raws); err != nil {
+		return
+	}
+
+	rules = make([]Rule, len(raws))
+	for i, raw := range raws {
+		if err = json.Unmarshal(raw, &rules[i]); err != nil {
+			return
+		}
+
+		var enabled struct {
+			Enabled *bool `json:"enabled"`
+		}
+		if err = json.Unmarshal(raw, &enabled); err != nil {
+			return
+		}
+		if enabled.Enabled != nil && !*enabled.Enabled {
+			rules[i].Disable()
+		} else {
+			rules[i].Enable()
+		}
+
+		params := normalizeJSONParams(rules[i].Consequence.Params)
+		for _, k := range []string{"automaticFacetFilters", "automaticOptionalFacetFilters"} {
+			if filters, ok := params[k].([]interface{}); ok {
+				var typed []AutomaticFacetFilter
+				if err = decodeInto(filters, &typed, k); err != nil {
+					return
+				}
+				params[k] = typed
+			}
+		}
+		rules[i].Consequence.Params = params
+	}
+
+	return
+}
+
+// jsonParamsAsInterfaceSlices are the query parameters which are only valid
+// as `[]interface{}` slices and are therefore kept as such by
+// `normalizeJSONParams`.
+var jsonParamsAsInterfaceSlices = map[string]bool{
+	"numericFilters": true,
+	"tagFilters":     true,
+}
+
+// normalizeJSONParams converts the query parameters decoded from JSON to the
+// types expected by `checkQuery`: integral numbers become `int`, arrays of
+// strings or of numbers become `[]string` or `[]float64` (or slices of such
+// slices) and objects become `Map`.
+func normalizeJSONParams(params Map) Map {
+	if params == nil {
+		return nil
+	}
+
+	normalized := make(Map, len(params))
+	for k, v := range params {
+		if _, ok := v.([]interface{}); ok && jsonParamsAsInterfaceSlices[k] {
+			normalized[k] = v
+			continue
+		}
+		normalized[k] = normalizeJSONValue(v)
+	}
+	return normalized
+}
+
+func normalizeJSONValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case float64:
+		if v == float64(int(v)) {
+			return int(v)
+		}
+	case map[string]interface{}:
+		return normalizeJSONParams(Map(v))
+	case []interface{}:
+		return normalizeJSONSlice(v)
+	}
+	return v
+}
+
+// normalizeJSONSlice converts a JSON array to the most specific slice type
+// holding all its elements. Numbers are kept as `float64` within arrays, as
+// they usually are coordinates.
+func normalizeJSONSlice(values []interface{}) interface{} {
+	if len(values) == 0 {
+		return values
+	}
+
+	elems := make([]interface{}, len(values))
+	for i, v := range values {
+		if nested, ok := v.([]interface{}); ok {
+			elems[i] = normalizeJSONSlice(nested)
+		} else if m, ok := v.(map[string]interface{}); ok {
+			elems[i] = normalizeJSONParams(Map(m))
+		} else {
+			elems[i] = v
+		}
+	}
+
+	switch elems[0].(type) {
+	case string:
+		res := make([]string, len(elems))
+		for i, e := range elems {
+			s, ok := e.(string)
+			if !ok {
+				return elems
+			}
+			res[i] = s
+		}
+		return res
+	case float64:
+		res := make([]float64, len(elems))
+		for i, e := range elems {
+			f, ok := e.(float64)
+			if !ok {
+				return elems
+			}
+			res[i] = f
+		}
+		return res
+	case []string:
+		res := make([][]string, len(elems))
+		for i, e := range elems {
+			s, ok := e.([]string)
+			if !ok {
+				return elems
+			}
+			res[i] = s
+		}
+		return res
+	case []float64:
+		res := make([][]float64, len(elems))
+		for i, e := range elems {
+			f, ok := e.([]float64)
+			if !ok {
+				return elems
+			}
+			res[i] = f
+		}
+		return res
+	}
+
+	return elems
+}
+
+// PlanIndexConfig computes the changes needed to make the index match its
+// configuration, by comparing the configuration with the current settings,
+// Rules and synonyms of the index. The parts of the configuration which are
+// nil are not compared. All the requests are bound to `ctx`.
+func PlanIndexConfig(ctx context.Context, index Index, config IndexConfig) (plan IndexConfigPlan, err error) {
+	plan.IndexName = config.IndexName
+	plan.config = config
+	opts := &RequestOptions{Context: ctx}
+
+	if config.Settings != nil {
+		var current Settings
+		if current, err = index.GetSettingsWithRequestOptions(opts); err != nil {
+			return
+		}
+		plan.Settings = DiffSettings(current, *config.Settings)
+	}
+
+	if config.Rules != nil {
+		current := make(map[string]interface{})
+		it := NewRuleIteratorWithOptions(ctx, index, IteratorOptions{HitsPerPage: archiveBatchSize, RequestOptions: opts})
+		for {
+			var rule *Rule
+			if rule, err = it.Next(); err == NoMoreRulesErr {
+				err = nil
+				break
+			} else if err != nil {
+				return
+			}
+			rule.HighlightResult = nil
+			current[rule.ObjectID] = *rule
+		}
+
+		desired := make(map[string]interface{}, len(config.Rules))
+		for _, rule := range config.Rules {
+			desired[rule.ObjectID] = rule
+		}
+
+		var toSave []string
+		toSave, plan.RulesToDelete = diffConfigObjects(current, desired)
+		for _, objectID := range toSave {
+			plan.RulesToSave = append(plan.RulesToSave, desired[objectID].(Rule))
+		}
+	}
+
+	if config.Synonyms != nil {
+		current := make(map[string]interface{})
+		it := NewSynonymIteratorWithOptions(ctx, index, IteratorOptions{HitsPerPage: archiveBatchSize, RequestOptions: opts})
+		for {
+			var synonym *Synonym
+			if synonym, err = it.Next(); err == NoMoreSynonymsErr {
+				err = nil
+				break
+			} else if err != nil {
+				return
+			}
+			synonym.HighlightResult = nil
+			current[synonym.ObjectID] = *synonym
+		}
+
+		desired := make(map[string]interface{}, len(config.Synonyms))
+		for _, synonym := range config.Synonyms {
+			desired[synonym.ObjectID] = synonym
+		}
+
+		var toSave []string
+		toSave, plan.SynonymsToDelete = diffConfigObjects(current, desired)
+		for _, objectID := range toSave {
+			plan.SynonymsToSave = append(plan.SynonymsToSave, desired[objectID].(Synonym))
+		}
+	}
+
+	return
+}
+
+// diffConfigObjects returns the sorted objectIDs of the `desired` objects
+// which are missing from `current` or different, and the ones of the
+// `current` objects which are not desired. Objects are compared through
+// their JSON representation.
+func diffConfigObjects(current, desired map[string]interface{}) (toSave, toDelete []string) {
+	for objectID, object := range desired {
+		if !settingValuesAreEqual(current[objectID], object) {
+			toSave = append(toSave, objectID)
+		}
+	}
+
+	for objectID := range current {
+		if _, ok := desired[objectID]; !ok {
+			toDelete = append(toDelete, objectID)
+		}
+	}
+
+	sort.Strings(toSave)
+	sort.Strings(toDelete)
+	return
+}
+
+// ApplyIndexConfigPlan applies the plan computed by `PlanIndexConfig` to the
+// index and waits for all the changes to be published. Only the changed
+// settings are sent while the Rules and synonyms, if any of them changed, are
+// all replaced by the ones of the configuration. Nothing is sent if the plan
+// is empty. All the requests are bound to `ctx`.
+func ApplyIndexConfigPlan(ctx context.Context, index Index, plan IndexConfigPlan) error {
+	if plan.IsEmpty() {
+		return nil
+	}
+	opts := &RequestOptions{Context: ctx}
+
+	rulesChanged := len(plan.RulesToSave) > 0 || len(plan.RulesToDelete) > 0
+	synonymsChanged := len(plan.SynonymsToSave) > 0 || len(plan.SynonymsToDelete) > 0
+	if (rulesChanged && plan.config.Rules == nil) || (synonymsChanged && plan.config.Synonyms == nil) {
+		return errors.New("ApplyIndexConfigPlan: the plan has not been computed by PlanIndexConfig")
+	}
+
+	// As the tasks of an index are processed sequentially, only the last task
+	// needs to be waited for.
+	var lastTask interface {
+		Wait(ctx context.Context) error
+	}
+
+	if len(plan.Settings) > 0 {
+		res, err := index.SetSettingsWithRequestOptions(plan.Settings.ToMap(), opts)
+		if err != nil {
+			return err
+		}
+		lastTask = res
+	}
+
+	if rulesChanged {
+		if len(plan.config.Rules) == 0 {
+			res, err := index.ClearRulesWithRequestOptions(false, opts)
+			if err != nil {
+				return err
+			}
+			lastTask = res
+		} else {
+			res, err := index.BatchRulesWithRequestOptions(plan.config.Rules, false, true, opts)
+			if err != nil {
+				return err
+			}
+			lastTask = res
+		}
+	}
+
+	if synonymsChanged {
+		if len(plan.config.Synonyms) == 0 {
+			res, err := index.ClearSynonymsWithRequestOptions(false, opts)
+			if err != nil {
+				return err
+			}
+			lastTask = res
+		} else {
+			res, err := index.BatchSynonymsWithRequestOptions(plan.config.Synonyms, true, false, opts)
+			if err != nil {
+				return err
+			}
+			lastTask = res
+		}
+	}
+
+	return lastTask.Wait(ctx)
+}
@@ -0,0 +1,234 @@
+package algoliasearch
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// routingRoundTripper answers each request with the response registered for
+// its method and path, or with a 404 if there is none, and records the
+// requests it receives.
+type routingRoundTripper struct {
+	mu        sync.Mutex
+	responses map[string]string
+	requests  []string
+	bodies    []string
+}
+
+func (rt *routingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		body, _ = ioutil.ReadAll(req.Body)
+	}
+
+	route := req.Method + " " + req.URL.Path
+
+	rt.mu.Lock()
+	rt.requests = append(rt.requests, route)
+	rt.bodies = append(rt.bodies, string(body))
+	response, ok := rt.responses[route]
+	rt.mu.Unlock()
+
+	code := 200
+	if !ok {
+		code, response = 404, `{"message":"not found"}`
+	}
+
+	return &http.Response{
+		StatusCode: code,
+		Body:       ioutil.NopCloser(bytes.NewBufferString(response)),
+		Request:    req,
+	}, nil
+}
+
+func writeConfigFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+	}
+}
+
+func TestLoadIndexConfigs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "TestLoadIndexConfigs")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	writeConfigFiles(t, dir, map[string]string{
+		"README.md":                  "Not an index",
+		"products/settings.json":     `{"hitsPerPage": 20, "distinct": 1, "ignorePlurals": ["en"]}`,
+		"products/synonyms.json":     `[{"objectID": "phones", "type": "synonym", "synonyms": ["phone", "smartphone"]}]`,
+		"products/rules.json":        `[{"objectID": "promote", "condition": {"pattern": "apple", "anchoring": "contains"}, "consequence": {"params": {"hitsPerPage": 5, "aroundLatLng": "48.8,2.3", "numericFilters": ["price > 10"], "facetFilters": [["brand:apple", "brand:samsung"]], "insideBoundingBox": [[1, 2, 3, 4]], "query": {"edits": [{"type": "remove", "delete": "apple"}]}, "automaticFacetFilters": [{"facet": "brand", "score": 2}]}}}, {"objectID": "disabled", "enabled": false, "condition": {"pattern": "old", "anchoring": "is"}, "consequence": {"params": {"query": "new"}}}]`,
+		"products_asc/settings.txt":  `customRanking=asc(price)`,
+		"products_desc/settings.yml": "customRanking:\n  - desc(price)\nhitsPerPage: 10\n",
+		"products_desc/rules.yaml": "" +
+			"- objectID: promote\n" +
+			"  condition: {pattern: apple, anchoring: contains}\n" +
+			"  consequence:\n" +
+			"    params:\n" +
+			"      hitsPerPage: 5\n" +
+			"      query: {edits: [{type: remove, delete: apple}]}\n",
+		"products_desc/synonyms.yaml": "- {objectID: phones, type: synonym, synonyms: [phone, smartphone]}\n",
+	})
+
+	t.Log("TestLoadIndexConfigs: Load JSON and YAML files, and others through converters")
+	{
+		configs, err := LoadIndexConfigs(dir, LoadIndexConfigOptions{
+			Converters: map[string]func([]byte) ([]byte, error){
+				".txt": func(data []byte) ([]byte, error) {
+					parts := strings.SplitN(strings.TrimSpace(string(data)), "=", 2)
+					return []byte(`{"` + parts[0] + `": ["` + parts[1] + `"]}`), nil
+				},
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, configs, 3)
+
+		products := configs[0]
+		require.Equal(t, "products", products.IndexName)
+		require.Equal(t, Int(20), products.Settings.HitsPerPage)
+		require.Equal(t, []Synonym{NewSynonym("phones", []string{"phone", "smartphone"})}, products.Synonyms)
+
+		require.Len(t, products.Rules, 2)
+		require.True(t, products.Rules[0].Enabled)
+		require.Equal(t, Map{
+			"hitsPerPage":       5,
+			"aroundLatLng":      "48.8,2.3",
+			"numericFilters":    []interface{}{"price > 10"},
+			"facetFilters":      [][]string{{"brand:apple", "brand:samsung"}},
+			"insideBoundingBox": [][]float64{{1, 2, 3, 4}},
+			"query": Map{"edits": []interface{}{
+				Map{"type": "remove", "delete": "apple"},
+			}},
+			"automaticFacetFilters": []AutomaticFacetFilter{{Facet: "brand", Score: 2}},
+		}, products.Rules[0].Consequence.Params)
+		require.False(t, products.Rules[1].Enabled)
+		require.True(t, products.Rules[1].isExplicitlyDisabled)
+
+		asc := configs[1]
+		require.Equal(t, "products_asc", asc.IndexName)
+		require.Equal(t, []string{"asc(price)"}, asc.Settings.CustomRanking)
+		require.Nil(t, asc.Rules)
+		require.Nil(t, asc.Synonyms)
+
+		desc := configs[2]
+		require.Equal(t, "products_desc", desc.IndexName)
+		require.Equal(t, []string{"desc(price)"}, desc.Settings.CustomRanking)
+		require.Equal(t, Int(10), desc.Settings.HitsPerPage)
+		require.Len(t, desc.Rules, 1)
+		require.Equal(t, NewSimpleRuleCondition(Contains, "apple"), desc.Rules[0].Condition)
+		require.Equal(t, Map{
+			"hitsPerPage": 5,
+			"query": Map{"edits": []interface{}{
+				Map{"type": "remove", "delete": "apple"},
+			}},
+		}, desc.Rules[0].Consequence.Params)
+		require.Equal(t, []Synonym{NewSynonym("phones", []string{"phone", "smartphone"})}, desc.Synonyms)
+	}
+
+	t.Log("TestLoadIndexConfigs: Reject invalid configurations")
+	for _, files := range []map[string]string{
+		{"invalid/settings.json": `{"hitsPerPage": "20"}`},
+		{"invalid/settings.json": `{"typoTolerance": 1}`},
+		{"invalid/rules.json": `[{"condition": {"pattern": "a", "anchoring": "is"}}]`},
+		{"invalid/rules.json": `[{"objectID": "a", "consequence": {"params": {"hitsPerPage": "5"}}}]`},
+		{"invalid/synonyms.json": `[{"objectID": "a", "type": "synonym"}]`},
+		{"invalid/synonyms.json": `[{"objectID": "a", "type": "unknown"}]`},
+		{"invalid/settings.yaml": "hitsPerPage: [20"},
+		{"invalid/settings.json": `{}`, "invalid/settings.yml": "{}"},
+	} {
+		invalidDir, err := ioutil.TempDir("", "TestLoadIndexConfigs")
+		require.NoError(t, err)
+		writeConfigFiles(t, invalidDir, files)
+		_, err = LoadIndexConfigs(invalidDir, LoadIndexConfigOptions{})
+		os.RemoveAll(invalidDir)
+		require.Error(t, err, "%v", files)
+	}
+}
+
+func TestPlanAndApplyIndexConfig(t *testing.T) {
+	rt := &routingRoundTripper{responses: map[string]string{
+		"GET /1/indexes/products/settings":         `{"hitsPerPage": 10, "customRanking": ["desc(popularity)"]}`,
+		"POST /1/indexes/products/rules/search":    `{"hits": [{"objectID": "kept", "enabled": true, "condition": {"pattern": "a", "anchoring": "is"}, "consequence": {"params": {"hitsPerPage": 5}}, "_highlightResult": {}}, {"objectID": "removed", "enabled": true, "condition": {"pattern": "b", "anchoring": "is"}, "consequence": {}}], "nbHits": 2, "page": 0, "nbPages": 1}`,
+		"POST /1/indexes/products/synonyms/search": `{"hits": [{"objectID": "phones", "type": "synonym", "synonyms": ["phone", "smartphone"]}], "nbHits": 1}`,
+		"PUT /1/indexes/products/settings":         `{"taskID": 1}`,
+		"POST /1/indexes/products/rules/batch":     `{"taskID": 2}`,
+		"GET /1/indexes/products/task/2":           `{"status": "published"}`,
+	}}
+	c := NewClient("appID", "apiKey")
+	c.SetHTTPClient(&http.Client{Transport: rt})
+	i := c.InitIndex("products")
+
+	kept := Rule{
+		ObjectID:    "kept",
+		Condition:   NewSimpleRuleCondition(Is, "a"),
+		Consequence: RuleConsequence{Params: Map{"hitsPerPage": 5}},
+	}
+	kept.Enable()
+	added := Rule{
+		ObjectID:    "added",
+		Condition:   NewSimpleRuleCondition(Is, "c"),
+		Consequence: RuleConsequence{Params: Map{"query": "c"}},
+	}
+	added.Enable()
+
+	config := IndexConfig{
+		IndexName: "products",
+		Settings:  &Settings{HitsPerPage: Int(20), CustomRanking: []string{"desc(popularity)"}},
+		Rules:     []Rule{kept, added},
+		Synonyms:  []Synonym{NewSynonym("phones", []string{"phone", "smartphone"})},
+	}
+
+	plan, err := PlanIndexConfig(context.Background(), i, config)
+	require.NoError(t, err)
+	require.Equal(t, SettingsDiff{{Setting: "hitsPerPage", Before: 10, After: 20}}, plan.Settings)
+	require.Equal(t, []Rule{added}, plan.RulesToSave)
+	require.Equal(t, []string{"removed"}, plan.RulesToDelete)
+	require.Empty(t, plan.SynonymsToSave)
+	require.Empty(t, plan.SynonymsToDelete)
+	require.False(t, plan.IsEmpty())
+	require.Equal(t, ""+
+		"index products:\n"+
+		"  ~ settings hitsPerPage: 10 -> 20\n"+
+		"  + rule added\n"+
+		"  - rule removed", plan.String())
+
+	rt.requests, rt.bodies = nil, nil
+	require.NoError(t, ApplyIndexConfigPlan(context.Background(), i, plan))
+	require.Equal(t, []string{
+		"PUT /1/indexes/products/settings",
+		"POST /1/indexes/products/rules/batch",
+		"GET /1/indexes/products/task/2",
+	}, rt.requests)
+	require.JSONEq(t, `{"hitsPerPage": 20}`, rt.bodies[0])
+	require.Contains(t, rt.bodies[1], `"objectID":"kept"`)
+	require.Contains(t, rt.bodies[1], `"objectID":"added"`)
+
+	t.Log("TestPlanAndApplyIndexConfig: Do not apply plans which have not been computed")
+	require.Error(t, ApplyIndexConfigPlan(context.Background(), i, IndexConfigPlan{RulesToDelete: []string{"removed"}}))
+	require.NoError(t, ApplyIndexConfigPlan(context.Background(), i, IndexConfigPlan{}))
+}
+
+func TestPlanIndexConfig_cancelledContext(t *testing.T) {
+	rt := &blockingRoundTripper{received: make(chan struct{}, 10)}
+	c := NewClient("appID", "apiKey")
+	c.SetHTTPClient(&http.Client{Transport: rt})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-rt.received
+		cancel()
+	}()
+
+	_, err := PlanIndexConfig(ctx, c.InitIndex("products"), IndexConfig{IndexName: "products", Settings: &Settings{}})
+	require.Equal(t, context.Canceled, err)
+}
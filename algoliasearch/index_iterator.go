@@ -1,26 +1,43 @@
 package algoliasearch
 
+import "fmt"
+
 type indexIterator struct {
-	cursor string
-	index  Index
-	opts   *RequestOptions
-	page   BrowseRes
-	params Map
-	pos    int
+	checkpointer Checkpointer
+	cursor       string
+	index        Index
+	opts         *RequestOptions
+	page         BrowseRes
+	pageCursor   string
+	params       Map
+	pos          int
 }
 
 // newIndexIterator instantiates a IndexIterator on the `index` and according
-// to the given `params`. It is also trying to load the first page of results
-// and return an error if something goes wrong.
-func newIndexIterator(index Index, params Map, opts *RequestOptions) (it *indexIterator, err error) {
+// to the given `params`. The iteration starts from the given `checkpoint`,
+// whose zero value denotes the first hit. It is also trying to load the first
+// page of results and return an error if something goes wrong.
+func newIndexIterator(index Index, params Map, checkpoint BrowseCheckpoint, checkpointer Checkpointer, opts *RequestOptions) (it *indexIterator, err error) {
 	it = &indexIterator{
-		cursor: "",
-		index:  index,
-		opts:   opts,
-		params: duplicateMap(params),
-		pos:    0,
+		checkpointer: checkpointer,
+		cursor:       checkpoint.Cursor,
+		index:        index,
+		opts:         opts,
+		params:       duplicateMap(params),
+		pos:          0,
+	}
+	if err = it.loadNextPage(); err != nil {
+		return
+	}
+
+	// Skip the hits of the page which were already returned before the
+	// checkpoint was taken.
+	if checkpoint.Position > 0 {
+		it.pos = checkpoint.Position
+		if it.pos > len(it.page.Hits) {
+			it.pos = len(it.page.Hits)
+		}
 	}
-	err = it.loadNextPage()
 	return
 }
 
@@ -45,6 +62,13 @@ func (it *indexIterator) Next() (res Map, err error) {
 		if err != nil {
 			return
 		}
+
+		if it.checkpointer != nil {
+			if err = it.checkpointer.SaveCheckpoint(it.Checkpoint()); err != nil {
+				err = fmt.Errorf("cannot save browse checkpoint: %s", err)
+				return
+			}
+		}
 	}
 
 	res = it.page.Hits[it.pos]
@@ -53,6 +77,13 @@ func (it *indexIterator) Next() (res Map, err error) {
 	return
 }
 
+func (it *indexIterator) Checkpoint() BrowseCheckpoint {
+	return BrowseCheckpoint{
+		Cursor:   it.pageCursor,
+		Position: it.pos,
+	}
+}
+
 // loadNextPage is used internally to load the next page of results, using the
 // underlying Browse cursor.
 func (it *indexIterator) loadNextPage() (err error) {
@@ -66,6 +97,7 @@ func (it *indexIterator) loadNextPage() (err error) {
 		return
 	}
 
+	it.pageCursor = it.cursor
 	it.cursor = it.page.Cursor
 	it.pos = 0
 	return
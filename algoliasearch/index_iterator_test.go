@@ -0,0 +1,101 @@
+package algoliasearch
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// browseStubIndex is an Index whose Browse method serves pre-defined pages
+// keyed by their cursor. Calling any other method panics.
+type browseStubIndex struct {
+	Index
+	pages map[string]BrowseRes
+}
+
+func (i browseStubIndex) BrowseWithRequestOptions(params Map, cursor string, opts *RequestOptions) (res BrowseRes, err error) {
+	return i.pages[cursor], nil
+}
+
+func newBrowseStubIndex() browseStubIndex {
+	page := func(cursor string, objectIDs ...string) (res BrowseRes) {
+		res.Cursor = cursor
+		for _, objectID := range objectIDs {
+			res.Hits = append(res.Hits, Map{"objectID": objectID})
+		}
+		return
+	}
+
+	return browseStubIndex{pages: map[string]BrowseRes{
+		"":       page("second", "1", "2"),
+		"second": page("third", "3", "4"),
+		"third":  page("", "5"),
+	}}
+}
+
+func iterateObjectIDs(it IndexIterator) (objectIDs []string, err error) {
+	for {
+		var hit Map
+		if hit, err = it.Next(); err != nil {
+			return
+		}
+		objectID, _ := Object(hit).ObjectID()
+		objectIDs = append(objectIDs, objectID)
+	}
+}
+
+func TestIndexIterator_checkpoints(t *testing.T) {
+	var checkpoints []BrowseCheckpoint
+	checkpointer := CheckpointerFunc(func(checkpoint BrowseCheckpoint) error {
+		checkpoints = append(checkpoints, checkpoint)
+		return nil
+	})
+
+	it, err := newIndexIterator(newBrowseStubIndex(), nil, BrowseCheckpoint{}, checkpointer, nil)
+	require.NoError(t, err)
+	require.Equal(t, BrowseCheckpoint{Cursor: "", Position: 0}, it.Checkpoint())
+
+	objectIDs, err := iterateObjectIDs(it)
+	require.Equal(t, NoMoreHitsErr, err)
+	require.Equal(t, []string{"1", "2", "3", "4", "5"}, objectIDs)
+	require.Equal(t, []BrowseCheckpoint{
+		{Cursor: "second", Position: 0},
+		{Cursor: "third", Position: 0},
+	}, checkpoints)
+	require.Equal(t, BrowseCheckpoint{Cursor: "third", Position: 1}, it.Checkpoint())
+}
+
+func TestIndexIterator_resume(t *testing.T) {
+	for _, c := range []struct {
+		checkpoint BrowseCheckpoint
+		expected   []string
+	}{
+		{BrowseCheckpoint{Cursor: "", Position: 0}, []string{"1", "2", "3", "4", "5"}},
+		{BrowseCheckpoint{Cursor: "", Position: 1}, []string{"2", "3", "4", "5"}},
+		{BrowseCheckpoint{Cursor: "second", Position: 0}, []string{"3", "4", "5"}},
+		{BrowseCheckpoint{Cursor: "second", Position: 2}, []string{"5"}},
+		{BrowseCheckpoint{Cursor: "third", Position: 42}, nil},
+	} {
+		it, err := newIndexIterator(newBrowseStubIndex(), nil, c.checkpoint, nil, nil)
+		require.NoError(t, err)
+
+		objectIDs, err := iterateObjectIDs(it)
+		require.Equal(t, NoMoreHitsErr, err)
+		require.Equal(t, c.expected, objectIDs, "unexpected hits when resuming from %#v", c.checkpoint)
+	}
+}
+
+func TestIndexIterator_checkpointerError(t *testing.T) {
+	checkpointer := CheckpointerFunc(func(checkpoint BrowseCheckpoint) error {
+		return errors.New("disk full")
+	})
+
+	it, err := newIndexIterator(newBrowseStubIndex(), nil, BrowseCheckpoint{}, checkpointer, nil)
+	require.NoError(t, err)
+
+	objectIDs, err := iterateObjectIDs(it)
+	require.Error(t, err)
+	require.NotEqual(t, NoMoreHitsErr, err)
+	require.Equal(t, []string{"1", "2"}, objectIDs)
+}
@@ -0,0 +1,48 @@
+package algoliasearch
+
+import "context"
+
+// IndexListIterator is the exposed structure to iterate over all the indexes
+// of an application, page by page.
+type IndexListIterator struct {
+	paginator *paginator
+	indexes   []IndexRes
+}
+
+// NewIndexListIterator returns a new IndexListIterator that will iterate over
+// all the indexes of the application of the given Client.
+func NewIndexListIterator(client Client) *IndexListIterator {
+	return NewIndexListIteratorWithOptions(context.Background(), client, IteratorOptions{})
+}
+
+// NewIndexListIteratorWithOptions is the same as NewIndexListIterator but the
+// iteration stops as soon as `ctx` is done and the pages are retrieved
+// according to the given IteratorOptions.
+func NewIndexListIteratorWithOptions(ctx context.Context, client Client, options IteratorOptions) *IndexListIterator {
+	it := &IndexListIterator{}
+	it.paginator = newPaginator(ctx, options.hitsPerPage(), NoMoreIndexesErr, func(page, hitsPerPage int) (int, bool, error) {
+		res, err := client.ListIndexesPageWithRequestOptions(page, hitsPerPage, options.RequestOptions)
+		if err != nil {
+			return 0, false, err
+		}
+
+		it.indexes = res.Items
+		return len(res.Items), page+1 >= res.NbPages, nil
+	})
+	return it
+}
+
+// Next returns the next index. Every call to Next should yield a different
+// index with a nil error until the algoliasearch.NoMoreIndexesErr is returned
+// which means that all the indexes have been retrieved. If the error is of a
+// different type, it means that the iteration could not have been done
+// correctly.
+func (it *IndexListIterator) Next() (*IndexRes, error) {
+	pos, err := it.paginator.next()
+	if err != nil {
+		return nil, err
+	}
+
+	index := it.indexes[pos]
+	return &index, nil
+}
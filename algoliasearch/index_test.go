@@ -1,8 +1,13 @@
 package algoliasearch
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
 	"reflect"
 	"sort"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -154,24 +159,24 @@ func intSlicesAreEqual(s1, s2 []int) bool {
 // settingsAreEqualByComparable returns `true` if all the comparable fields of
 // the given Settings are the same. It returns `false` otherwise.
 func settingsAreEqualByComparable(s1, s2 Settings) bool {
-	return s1.AllowCompressionOfIntegerArray == s2.AllowCompressionOfIntegerArray &&
-		s1.AdvancedSyntax == s2.AdvancedSyntax &&
-		s1.AllowTyposOnNumericTokens == s2.AllowTyposOnNumericTokens &&
-		s1.AttributeForDistinct == s2.AttributeForDistinct &&
-		s1.HighlightPostTag == s2.HighlightPostTag &&
-		s1.HighlightPreTag == s2.HighlightPreTag &&
-		s1.HitsPerPage == s2.HitsPerPage &&
-		s1.IgnorePlurals == s2.IgnorePlurals &&
-		s1.KeepDiacriticsOnCharacters == s2.KeepDiacriticsOnCharacters &&
-		s1.MaxValuesPerFacet == s2.MaxValuesPerFacet &&
-		s1.MinProximity == s2.MinProximity &&
-		s1.MinWordSizefor1Typo == s2.MinWordSizefor1Typo &&
-		s1.MinWordSizefor2Typos == s2.MinWordSizefor2Typos &&
-		s1.QueryType == s2.QueryType &&
-		s1.ReplaceSynonymsInHighlight == s2.ReplaceSynonymsInHighlight &&
-		s1.SeparatorsToIndex == s2.SeparatorsToIndex &&
-		s1.SnippetEllipsisText == s2.SnippetEllipsisText &&
-		s1.TypoTolerance == s2.TypoTolerance
+	return reflect.DeepEqual(s1.AllowCompressionOfIntegerArray, s2.AllowCompressionOfIntegerArray) &&
+		reflect.DeepEqual(s1.AdvancedSyntax, s2.AdvancedSyntax) &&
+		reflect.DeepEqual(s1.AllowTyposOnNumericTokens, s2.AllowTyposOnNumericTokens) &&
+		reflect.DeepEqual(s1.AttributeForDistinct, s2.AttributeForDistinct) &&
+		reflect.DeepEqual(s1.HighlightPostTag, s2.HighlightPostTag) &&
+		reflect.DeepEqual(s1.HighlightPreTag, s2.HighlightPreTag) &&
+		reflect.DeepEqual(s1.HitsPerPage, s2.HitsPerPage) &&
+		reflect.DeepEqual(s1.IgnorePlurals, s2.IgnorePlurals) &&
+		reflect.DeepEqual(s1.KeepDiacriticsOnCharacters, s2.KeepDiacriticsOnCharacters) &&
+		reflect.DeepEqual(s1.MaxValuesPerFacet, s2.MaxValuesPerFacet) &&
+		reflect.DeepEqual(s1.MinProximity, s2.MinProximity) &&
+		reflect.DeepEqual(s1.MinWordSizefor1Typo, s2.MinWordSizefor1Typo) &&
+		reflect.DeepEqual(s1.MinWordSizefor2Typos, s2.MinWordSizefor2Typos) &&
+		reflect.DeepEqual(s1.QueryType, s2.QueryType) &&
+		reflect.DeepEqual(s1.ReplaceSynonymsInHighlight, s2.ReplaceSynonymsInHighlight) &&
+		reflect.DeepEqual(s1.SeparatorsToIndex, s2.SeparatorsToIndex) &&
+		reflect.DeepEqual(s1.SnippetEllipsisText, s2.SnippetEllipsisText) &&
+		reflect.DeepEqual(s1.TypoTolerance, s2.TypoTolerance)
 }
 
 // settingsAreEqualByStringSlices returns `true` if all the string slices of
@@ -303,10 +308,10 @@ func TestSettings(t *testing.T) {
 	_, i := initClientAndIndex(t, "TestSettings")
 
 	expectedSettings := Settings{
-		AdvancedSyntax:                   true,
-		AllowCompressionOfIntegerArray:   false,
-		AllowTyposOnNumericTokens:        false,
-		AttributeForDistinct:             "attribute",
+		AdvancedSyntax:                   Bool(true),
+		AllowCompressionOfIntegerArray:   Bool(false),
+		AllowTyposOnNumericTokens:        Bool(false),
+		AttributeForDistinct:             String("attribute"),
 		AttributesForFaceting:            []string{"attribute"},
 		AttributesToHighlight:            []string{"attribute"},
 		AttributesToRetrieve:             []string{"attribute"},
@@ -317,27 +322,27 @@ func TestSettings(t *testing.T) {
 		DisableTypoToleranceOnAttributes: []string{"attribute"},
 		DisableTypoToleranceOnWords:      []string{"word"},
 		Distinct:                         true,
-		HighlightPostTag:                 "<p>",
-		HighlightPreTag:                  "</p>",
-		HitsPerPage:                      10,
+		HighlightPostTag:                 String("<p>"),
+		HighlightPreTag:                  String("</p>"),
+		HitsPerPage:                      Int(10),
 		IgnorePlurals:                    true,
-		KeepDiacriticsOnCharacters:       "éø",
-		MaxValuesPerFacet:                20,
-		MinProximity:                     2,
-		MinWordSizefor1Typo:              2,
-		MinWordSizefor2Typos:             4,
+		KeepDiacriticsOnCharacters:       String("éø"),
+		MaxValuesPerFacet:                Int(20),
+		MinProximity:                     Int(2),
+		MinWordSizefor1Typo:              Int(2),
+		MinWordSizefor2Typos:             Int(4),
 		NumericAttributesForFiltering:    []string{"attribute"},
 		OptionalWords:                    []string{"optional", "words"},
 		QueryLanguages:                   []string{"en", "fr"},
-		QueryType:                        "prefixAll",
+		QueryType:                        String("prefixAll"),
 		Ranking:                          []string{"typo", "geo", "words", "proximity", "attribute", "exact", "custom"},
 		RemoveStopWords:                  []string{"en", "fr"},
-		ReplaceSynonymsInHighlight:       false,
+		ReplaceSynonymsInHighlight:       Bool(false),
 		Replicas:                         []string{},
 		ResponseFields:                   []string{"hits", "query"},
 		SearchableAttributes:             []string{"attribute"},
-		SeparatorsToIndex:                "+#",
-		SnippetEllipsisText:              "...",
+		SeparatorsToIndex:                String("+#"),
+		SnippetEllipsisText:              String("..."),
 		TypoTolerance:                    "strict",
 		UnretrievableAttributes:          []string{"unretrievable_attribute"},
 	}
@@ -1089,27 +1094,87 @@ func TestSettingsToMap(t *testing.T) {
 
 func TestSettingsToMap_allRequiredFieldsArePresent(t *testing.T) {
 	var settings Settings
+	require.Empty(t, settings.ToMap(), "unset fields should not be sent")
 
-	m := settings.ToMap()
-
+	// Set all the fields to their zero value, which should still be sent.
 	s := reflect.ValueOf(&settings).Elem()
 	tt := s.Type()
+	for i := 0; i < s.NumField(); i++ {
+		switch f := s.Field(i); f.Kind() {
+		case reflect.Ptr:
+			f.Set(reflect.New(tt.Field(i).Type.Elem()))
+		case reflect.Slice:
+			f.Set(reflect.MakeSlice(tt.Field(i).Type, 0, 0))
+		case reflect.Map:
+			f.Set(reflect.MakeMap(tt.Field(i).Type))
+		case reflect.Interface:
+			f.Set(reflect.ValueOf(false))
+		}
+	}
+
+	m := settings.ToMap()
 
 	for i := 0; i < s.NumField(); i++ {
-		// Skip []string-type fields because those are discarded to avoid
-		// sending useless data to the API.
-		if tt.Field(i).Type.String() == "[]string" {
+		expectedSettingName := strings.Split(tt.Field(i).Tag.Get("json"), ",")[0]
+		if expectedSettingName == "primary" {
+			require.NotContains(t, m, expectedSettingName, "read-only settings should not be sent")
 			continue
 		}
 
-		expectedSettingName := tt.Field(i).Name
-		tmp := []rune(expectedSettingName)
-		tmp[0] = unicode.ToLower(tmp[0])
-		expectedSettingName = string(tmp)
-
 		_, ok := m[expectedSettingName]
 		require.True(t, ok, "should find '%s' setting in the result map", expectedSettingName)
+
+		tmp := []rune(tt.Field(i).Name)
+		tmp[0] = unicode.ToLower(tmp[0])
+		require.Equal(t, string(tmp), expectedSettingName)
 	}
+
+	require.NoError(t, checkSettings(m))
+}
+
+func TestSettingsToMap_roundTrip(t *testing.T) {
+	payload := `{
+		"hitsPerPage": 0,
+		"enableRules": false,
+		"typoTolerance": "min",
+		"distinct": 2,
+		"ignorePlurals": ["en", "fr"],
+		"removeStopWords": true,
+		"replicas": [],
+		"customRanking": null,
+		"primary": "products",
+		"userData": {"version": 3}
+	}`
+
+	var settings Settings
+	require.NoError(t, json.Unmarshal([]byte(payload), &settings))
+
+	m := settings.ToMap()
+	require.Equal(t, Map{
+		"hitsPerPage":     0,
+		"enableRules":     false,
+		"typoTolerance":   "min",
+		"distinct":        2,
+		"ignorePlurals":   []string{"en", "fr"},
+		"removeStopWords": true,
+		"replicas":        []string{},
+		"userData":        map[string]interface{}{"version": 3.0},
+	}, m)
+	require.NoError(t, checkSettings(m))
+
+	t.Log("TestSettingsToMap_roundTrip: Settings should also survive a JSON round trip")
+	data, err := json.Marshal(settings)
+	require.NoError(t, err)
+	var decoded Settings
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, m, decoded.ToMap())
+
+	t.Log("TestSettingsToMap_roundTrip: Keep invalid types for SetSettings to report them")
+	settings.IgnorePlurals = []interface{}{"en", 42.0}
+	settings.TypoTolerance = 1.0
+	m = settings.ToMap()
+	require.Equal(t, []interface{}{"en", 42.0}, m["ignorePlurals"])
+	require.Error(t, checkSettings(m))
 }
 
 func facetHitSliceAreEqual(fs1, fs2 []FacetHit) bool {
@@ -1750,3 +1815,87 @@ func TestBrowseAll(t *testing.T) {
 		require.Equal(t, 3501, count, "should browse all the records")
 	}
 }
+
+func TestDeleteByQueryInBatches_progress(t *testing.T) {
+	rt := &routingRoundTripper{responses: map[string]string{
+		"POST /1/indexes/products/browse": `{"hits": [{"objectID":"0"},{"objectID":"1"},{"objectID":"2"},{"objectID":"3"},{"objectID":"4"},{"objectID":"5"},{"objectID":"6"},{"objectID":"7"},{"objectID":"8"},{"objectID":"9"},{"objectID":"10"},{"objectID":"11"},{"objectID":"12"},{"objectID":"13"},{"objectID":"14"},{"objectID":"15"},{"objectID":"16"},{"objectID":"17"},{"objectID":"18"},{"objectID":"19"},{"objectID":"20"},{"objectID":"21"},{"objectID":"22"},{"objectID":"23"},{"objectID":"24"}]}`,
+		"POST /1/indexes/products/batch":  `{"taskID": 1}`,
+		"GET /1/indexes/products/task/1":  `{"status": "published"}`,
+	}}
+	c := NewClient("appID", "apiKey")
+	c.SetHTTPClient(&http.Client{Transport: rt})
+	i := c.InitIndex("products")
+
+	var progress []DeleteByQueryRes
+	res, err := i.DeleteByQueryInBatches(context.Background(), "", nil, DeleteByQueryOptions{
+		BatchSize: 10,
+		Progress: func(p DeleteByQueryRes) {
+			progress = append(progress, p)
+			p.TaskIDs = append(p.TaskIDs, 42)
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 1, 1}, res.TaskIDs)
+	require.Equal(t, []DeleteByQueryRes{
+		{NbMatched: 10, NbDeleted: 10, TaskIDs: []int{1}},
+		{NbMatched: 20, NbDeleted: 20, TaskIDs: []int{1, 1}},
+		{NbMatched: 25, NbDeleted: 25, TaskIDs: []int{1, 1, 1}},
+	}, progress)
+}
+
+func TestDeleteByQueryInBatches(t *testing.T) {
+	t.Parallel()
+	_, i := initClientAndIndex(t, "TestDeleteByQueryInBatches")
+
+	t.Log("TestDeleteByQueryInBatches: Add 25 matching and 5 non-matching records")
+	{
+		var objects []Object
+		for j := 0; j < 25; j++ {
+			objects = append(objects, Object{"objectID": fmt.Sprintf("match_%d", j), "company": "Algolia"})
+		}
+		for j := 0; j < 5; j++ {
+			objects = append(objects, Object{"objectID": fmt.Sprintf("other_%d", j), "company": "Other"})
+		}
+		res, err := i.AddObjects(objects)
+		require.NoError(t, err)
+		waitTask(t, i, res.TaskID)
+	}
+
+	ctx := context.Background()
+
+	t.Log("TestDeleteByQueryInBatches: Count the matching records with a dry run")
+	{
+		res, err := i.DeleteByQueryInBatches(ctx, "algolia", nil, DeleteByQueryOptions{DryRun: true})
+		require.NoError(t, err)
+		require.Equal(t, 25, res.NbMatched)
+		require.Equal(t, 0, res.NbDeleted)
+		require.Empty(t, res.TaskIDs)
+	}
+
+	t.Log("TestDeleteByQueryInBatches: Stop when the context is cancelled")
+	{
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		_, err := i.DeleteByQueryInBatches(cancelled, "algolia", nil, DeleteByQueryOptions{})
+		require.Equal(t, context.Canceled, err)
+	}
+
+	t.Log("TestDeleteByQueryInBatches: Delete the matching records by batches of 10")
+	{
+		var progress []DeleteByQueryRes
+		res, err := i.DeleteByQueryInBatches(ctx, "algolia", nil, DeleteByQueryOptions{
+			BatchSize: 10,
+			Progress:  func(p DeleteByQueryRes) { progress = append(progress, p) },
+		})
+		require.NoError(t, err)
+		require.Equal(t, 25, res.NbMatched)
+		require.Equal(t, 25, res.NbDeleted)
+		require.Len(t, res.TaskIDs, 3)
+		require.NotEmpty(t, progress)
+		require.Equal(t, 25, progress[len(progress)-1].NbDeleted)
+
+		searchRes, err := i.Search("", nil)
+		require.NoError(t, err)
+		require.Equal(t, 5, searchRes.NbHits)
+	}
+}
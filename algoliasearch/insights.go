@@ -0,0 +1,138 @@
+package algoliasearch
+
+import "time"
+
+type insights struct {
+	client *client
+	route  string
+}
+
+func NewInsights(client *client) *insights {
+	return &insights{
+		client: client,
+		route:  "/1/events",
+	}
+}
+
+func (i *insights) SendEvent(event InsightsEvent) (res InsightsRes, err error) {
+	return i.SendEventsWithRequestOptions([]InsightsEvent{event}, nil)
+}
+
+func (i *insights) SendEvents(events []InsightsEvent) (res InsightsRes, err error) {
+	return i.SendEventsWithRequestOptions(events, nil)
+}
+
+func (i *insights) SendEventsWithRequestOptions(events []InsightsEvent, opts *RequestOptions) (res InsightsRes, err error) {
+	if err = checkInsightsEvents(events); err != nil {
+		return
+	}
+
+	// The events are sent by batches of at most 1000 events, which is the
+	// limit of the API.
+	for start := 0; start < len(events); start += maxInsightsEventsPerRequest {
+		end := start + maxInsightsEventsPerRequest
+		if end > len(events) {
+			end = len(events)
+		}
+
+		body := map[string][]InsightsEvent{"events": events[start:end]}
+		if err = i.client.request(&res, "POST", i.route, body, insightsCall, opts); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func (i *insights) User(userToken string) UserInsights {
+	return &userInsights{
+		insights:  i,
+		userToken: userToken,
+	}
+}
+
+type userInsights struct {
+	insights  *insights
+	userToken string
+}
+
+func (u *userInsights) send(event InsightsEvent) (res InsightsRes, err error) {
+	event.UserToken = u.userToken
+	event.Timestamp = time.Now().UnixNano() / int64(time.Millisecond)
+	return u.insights.SendEvent(event)
+}
+
+func (u *userInsights) ClickedObjectIDs(eventName, indexName string, objectIDs []string) (res InsightsRes, err error) {
+	return u.send(InsightsEvent{
+		EventType: InsightsClickEvent,
+		EventName: eventName,
+		Index:     indexName,
+		ObjectIDs: objectIDs,
+	})
+}
+
+func (u *userInsights) ClickedObjectIDsAfterSearch(eventName, indexName string, objectIDs []string, positions []int, queryID string) (res InsightsRes, err error) {
+	return u.send(InsightsEvent{
+		EventType: InsightsClickEvent,
+		EventName: eventName,
+		Index:     indexName,
+		ObjectIDs: objectIDs,
+		Positions: positions,
+		QueryID:   queryID,
+	})
+}
+
+func (u *userInsights) ClickedFilters(eventName, indexName string, filters []string) (res InsightsRes, err error) {
+	return u.send(InsightsEvent{
+		EventType: InsightsClickEvent,
+		EventName: eventName,
+		Index:     indexName,
+		Filters:   filters,
+	})
+}
+
+func (u *userInsights) ConvertedObjectIDs(eventName, indexName string, objectIDs []string) (res InsightsRes, err error) {
+	return u.send(InsightsEvent{
+		EventType: InsightsConversionEvent,
+		EventName: eventName,
+		Index:     indexName,
+		ObjectIDs: objectIDs,
+	})
+}
+
+func (u *userInsights) ConvertedObjectIDsAfterSearch(eventName, indexName string, objectIDs []string, queryID string) (res InsightsRes, err error) {
+	return u.send(InsightsEvent{
+		EventType: InsightsConversionEvent,
+		EventName: eventName,
+		Index:     indexName,
+		ObjectIDs: objectIDs,
+		QueryID:   queryID,
+	})
+}
+
+func (u *userInsights) ConvertedFilters(eventName, indexName string, filters []string) (res InsightsRes, err error) {
+	return u.send(InsightsEvent{
+		EventType: InsightsConversionEvent,
+		EventName: eventName,
+		Index:     indexName,
+		Filters:   filters,
+	})
+}
+
+func (u *userInsights) ViewedObjectIDs(eventName, indexName string, objectIDs []string) (res InsightsRes, err error) {
+	return u.send(InsightsEvent{
+		EventType: InsightsViewEvent,
+		EventName: eventName,
+		Index:     indexName,
+		ObjectIDs: objectIDs,
+	})
+}
+
+func (u *userInsights) ViewedFilters(eventName, indexName string, filters []string) (res InsightsRes, err error) {
+	return u.send(InsightsEvent{
+		EventType: InsightsViewEvent,
+		EventName: eventName,
+		Index:     indexName,
+		Filters:   filters,
+	})
+}
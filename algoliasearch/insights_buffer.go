@@ -0,0 +1,174 @@
+package algoliasearch
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+)
+
+// InsightsBuffer accumulates Insights events on the server side and sends
+// them by batches, either once enough events are buffered, periodically, or
+// when explicitly flushed. It is safe for concurrent use.
+type InsightsBuffer struct {
+	insights Insights
+	options  InsightsBufferOptions
+
+	mu     sync.Mutex
+	events []InsightsEvent
+
+	// flushMu serializes the flushes so that the events are sent in order.
+	flushMu sync.Mutex
+
+	done   chan struct{}
+	closed sync.Once
+	wg     sync.WaitGroup
+}
+
+// NewInsightsBuffer returns a new InsightsBuffer sending its events through
+// `insights`. If `options.FlushInterval` is set, a background goroutine
+// flushes the buffer periodically until Close is called.
+func NewInsightsBuffer(insights Insights, options InsightsBufferOptions) *InsightsBuffer {
+	if options.MaxEvents <= 0 || options.MaxEvents > maxInsightsEventsPerRequest {
+		options.MaxEvents = maxInsightsEventsPerRequest
+	}
+	if options.MaxBufferedEvents <= 0 {
+		options.MaxBufferedEvents = 10 * options.MaxEvents
+	} else if options.MaxBufferedEvents < options.MaxEvents {
+		options.MaxBufferedEvents = options.MaxEvents
+	}
+
+	b := &InsightsBuffer{
+		insights: insights,
+		options:  options,
+		done:     make(chan struct{}),
+	}
+
+	if options.FlushInterval > 0 {
+		b.wg.Add(1)
+		go b.flushPeriodically()
+	}
+
+	return b
+}
+
+// Add validates and buffers the given events. If the buffer then holds at
+// least `MaxEvents` events, it is flushed and the error of the flush, if any,
+// is returned.
+func (b *InsightsBuffer) Add(events ...InsightsEvent) error {
+	if err := checkInsightsEvents(events); err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	b.events = append(b.events, events...)
+	full := len(b.events) >= b.options.MaxEvents
+	b.mu.Unlock()
+
+	if full {
+		return b.Flush()
+	}
+	return nil
+}
+
+// Len returns the number of events currently buffered.
+func (b *InsightsBuffer) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.events)
+}
+
+// Flush sends all the buffered events. If they cannot be sent because of a
+// network or server error, they are put back in the buffer so that the next
+// flush sends them again, up to `MaxBufferedEvents`. Events which are
+// rejected by the API, or which no longer fit in the buffer, are dropped and
+// reported by an InsightsDroppedEventsErr.
+func (b *InsightsBuffer) Flush() error {
+	b.flushMu.Lock()
+	defer b.flushMu.Unlock()
+
+	b.mu.Lock()
+	events := b.events
+	b.events = nil
+	b.mu.Unlock()
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	_, err := b.insights.SendEvents(events)
+	if err == nil {
+		return nil
+	}
+
+	if !isRetryableInsightsError(err) {
+		return &InsightsDroppedEventsErr{Events: events, Err: err}
+	}
+
+	b.mu.Lock()
+	events = append(events, b.events...)
+	var dropped []InsightsEvent
+	if extra := len(events) - b.options.MaxBufferedEvents; extra > 0 {
+		dropped, events = events[:extra], events[extra:]
+	}
+	b.events = events
+	b.mu.Unlock()
+
+	if len(dropped) > 0 {
+		return &InsightsDroppedEventsErr{Events: dropped, Err: err}
+	}
+	return err
+}
+
+// isRetryableInsightsError returns true if the events could not be sent
+// because of a network or server error, in which case sending them again
+// may succeed, and false if they have been rejected by the API.
+func isRetryableInsightsError(err error) bool {
+	if err == ExhaustionOfTryableHostsErr || err == context.DeadlineExceeded || err == context.Canceled {
+		return true
+	}
+	_, ok := err.(net.Error)
+	return ok
+}
+
+// InsightsDroppedEventsErr is the error returned by `InsightsBuffer.Flush`
+// when some events have been dropped from the buffer, either because they
+// have been rejected by the API or because the buffer was full. `Err` is the
+// error of the failed flush.
+type InsightsDroppedEventsErr struct {
+	Events []InsightsEvent
+	Err    error
+}
+
+func (e *InsightsDroppedEventsErr) Error() string {
+	return fmt.Sprintf("%d Insights event(s) dropped: %s", len(e.Events), e.Err)
+}
+
+// Close stops the periodic flush, if any, and flushes the remaining events.
+func (b *InsightsBuffer) Close() error {
+	b.closed.Do(func() { close(b.done) })
+	b.wg.Wait()
+	return b.Flush()
+}
+
+func (b *InsightsBuffer) flushPeriodically() {
+	defer b.wg.Done()
+
+	ticker := time.NewTicker(b.options.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-b.done:
+			return
+		case <-ticker.C:
+			if err := b.Flush(); err != nil {
+				debug("* INSIGHTS BUFFER flush error: %s", err)
+				if b.options.OnError != nil {
+					b.options.OnError(err)
+				}
+			}
+		}
+	}
+}
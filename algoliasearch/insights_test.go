@@ -0,0 +1,250 @@
+package algoliasearch
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/algolia/algoliasearch-client-go/algoliasearch/call"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingRoundTripper records the requests it receives and answers them
+// all with the same successful response, which is `response` if non-empty.
+type recordingRoundTripper struct {
+	mu       sync.Mutex
+	requests []*http.Request
+	bodies   []string
+	response string
+}
+
+func (rt *recordingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		body, _ = ioutil.ReadAll(req.Body)
+	}
+
+	rt.mu.Lock()
+	rt.requests = append(rt.requests, req)
+	rt.bodies = append(rt.bodies, string(body))
+	rt.mu.Unlock()
+
+	response := rt.response
+	if response == "" {
+		response = `{"status":200,"message":"OK"}`
+	}
+
+	return &http.Response{
+		StatusCode: 200,
+		Body:       ioutil.NopCloser(strings.NewReader(response)),
+		Request:    req,
+	}, nil
+}
+
+func TestRetryStrategy_insightsHost(t *testing.T) {
+	for _, hosts := range [][]string{nil, {"example.com"}} {
+		strategy := NewRetryStrategy("appID", hosts)
+		expected := &tryableHost{"insights.algolia.io", DefaultAnalyticsTimeout}
+		require.ElementsMatch(t, []TryableHost{expected}, strategy.GetTryableHosts(call.Insights))
+	}
+}
+
+func TestUserInsights(t *testing.T) {
+	rt := &recordingRoundTripper{}
+	c := NewClient("appID", "apiKey")
+	c.SetHTTPClient(&http.Client{Transport: rt})
+
+	res, err := c.InitInsights().User("user-42").ClickedObjectIDsAfterSearch("Product Clicked", "products", []string{"1", "2"}, []int{3, 7}, "queryID")
+	require.NoError(t, err)
+	require.Equal(t, InsightsRes{Status: 200, Message: "OK"}, res)
+
+	require.Len(t, rt.requests, 1)
+	require.Equal(t, "POST", rt.requests[0].Method)
+	require.Equal(t, "insights.algolia.io", rt.requests[0].URL.Host)
+	require.Equal(t, "/1/events", rt.requests[0].URL.Path)
+	require.Equal(t, "appID", rt.requests[0].Header.Get("X-Algolia-Application-Id"))
+
+	var body struct {
+		Events []InsightsEvent `json:"events"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(rt.bodies[0]), &body))
+	require.Len(t, body.Events, 1)
+
+	event := body.Events[0]
+	require.NotZero(t, event.Timestamp)
+	event.Timestamp = 0
+	require.Equal(t, InsightsEvent{
+		EventType: InsightsClickEvent,
+		EventName: "Product Clicked",
+		Index:     "products",
+		UserToken: "user-42",
+		QueryID:   "queryID",
+		ObjectIDs: []string{"1", "2"},
+		Positions: []int{3, 7},
+	}, event)
+}
+
+func TestInsights_SendEvents_batches(t *testing.T) {
+	rt := &recordingRoundTripper{}
+	c := NewClient("appID", "apiKey")
+	c.SetHTTPClient(&http.Client{Transport: rt})
+
+	events := make([]InsightsEvent, 2500)
+	for n := range events {
+		events[n] = InsightsEvent{
+			EventType: InsightsViewEvent,
+			EventName: "Filter Viewed",
+			Index:     "products",
+			UserToken: "user-42",
+			Filters:   []string{"brand:apple"},
+		}
+	}
+
+	_, err := c.InitInsights().SendEvents(events)
+	require.NoError(t, err)
+	require.Len(t, rt.requests, 3)
+}
+
+func TestCheckInsightsEvent(t *testing.T) {
+	valid := InsightsEvent{
+		EventType: InsightsConversionEvent,
+		EventName: "Product Bought",
+		Index:     "products",
+		UserToken: "user-42",
+		ObjectIDs: []string{"1"},
+	}
+	require.NoError(t, checkInsightsEvent(valid))
+
+	for _, modify := range []func(e *InsightsEvent){
+		func(e *InsightsEvent) { e.EventType = "purchase" },
+		func(e *InsightsEvent) { e.EventName = "" },
+		func(e *InsightsEvent) { e.EventName = strings.Repeat("a", 65) },
+		func(e *InsightsEvent) { e.Index = "" },
+		func(e *InsightsEvent) { e.UserToken = "" },
+		func(e *InsightsEvent) { e.UserToken = "user 42" },
+		func(e *InsightsEvent) { e.ObjectIDs = nil },
+		func(e *InsightsEvent) { e.Filters = []string{"brand:apple"} },
+		func(e *InsightsEvent) { e.ObjectIDs = make([]string, 21) },
+		func(e *InsightsEvent) { e.Positions = []int{1} },
+		func(e *InsightsEvent) { e.EventType, e.QueryID = InsightsClickEvent, "queryID" },
+		func(e *InsightsEvent) {
+			e.EventType, e.QueryID, e.Positions = InsightsClickEvent, "queryID", []int{1, 2}
+		},
+	} {
+		event := valid
+		modify(&event)
+		require.Error(t, checkInsightsEvent(event), "should reject the following event: %#v", event)
+	}
+}
+
+// stubInsights is an Insights whose SendEvents method records the events it
+// receives, or fails while `err` is set. Calling any other method panics.
+type stubInsights struct {
+	Insights
+	mu      sync.Mutex
+	batches [][]InsightsEvent
+	err     error
+}
+
+func (i *stubInsights) SendEvents(events []InsightsEvent) (res InsightsRes, err error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.err != nil {
+		return res, i.err
+	}
+	i.batches = append(i.batches, events)
+	return
+}
+
+func (i *stubInsights) nbBatches() int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return len(i.batches)
+}
+
+func viewEvent(objectID string) InsightsEvent {
+	return InsightsEvent{
+		EventType: InsightsViewEvent,
+		EventName: "Product Viewed",
+		Index:     "products",
+		UserToken: "user-42",
+		ObjectIDs: []string{objectID},
+	}
+}
+
+func TestInsightsBuffer(t *testing.T) {
+	insights := &stubInsights{}
+	b := NewInsightsBuffer(insights, InsightsBufferOptions{MaxEvents: 3})
+
+	require.Error(t, b.Add(InsightsEvent{}), "should reject invalid events")
+
+	require.NoError(t, b.Add(viewEvent("1"), viewEvent("2")))
+	require.Equal(t, 2, b.Len())
+	require.Equal(t, 0, insights.nbBatches())
+
+	require.NoError(t, b.Add(viewEvent("3")))
+	require.Equal(t, 0, b.Len())
+	require.Equal(t, 1, insights.nbBatches())
+	require.Len(t, insights.batches[0], 3)
+
+	insights.err = ExhaustionOfTryableHostsErr
+	require.NoError(t, b.Add(viewEvent("4")))
+	require.Equal(t, ExhaustionOfTryableHostsErr, b.Flush())
+	require.Equal(t, 1, b.Len(), "events should be kept after a failed flush")
+
+	insights.err = nil
+	require.NoError(t, b.Close())
+	require.Equal(t, 0, b.Len())
+	require.Equal(t, 2, insights.nbBatches())
+}
+
+func TestInsightsBuffer_droppedEvents(t *testing.T) {
+	insights := &stubInsights{}
+	b := NewInsightsBuffer(insights, InsightsBufferOptions{MaxEvents: 2, MaxBufferedEvents: 3})
+
+	t.Log("TestInsightsBuffer_droppedEvents: Drop the events rejected by the API")
+	{
+		insights.err = errors.New(`{"status":422,"message":"Invalid event"}`)
+		require.NoError(t, b.Add(viewEvent("1")))
+		err := b.Flush()
+		require.IsType(t, &InsightsDroppedEventsErr{}, err)
+		require.Equal(t, []InsightsEvent{viewEvent("1")}, err.(*InsightsDroppedEventsErr).Events)
+		require.Equal(t, insights.err, err.(*InsightsDroppedEventsErr).Err)
+		require.Equal(t, 0, b.Len())
+	}
+
+	t.Log("TestInsightsBuffer_droppedEvents: Drop the oldest events once the buffer is full")
+	{
+		insights.err = ExhaustionOfTryableHostsErr
+		require.Equal(t, ExhaustionOfTryableHostsErr, b.Add(viewEvent("2"), viewEvent("3")))
+		require.Equal(t, 2, b.Len())
+
+		err := b.Add(viewEvent("4"), viewEvent("5"))
+		require.IsType(t, &InsightsDroppedEventsErr{}, err)
+		require.Equal(t, []InsightsEvent{viewEvent("2")}, err.(*InsightsDroppedEventsErr).Events)
+		require.Equal(t, 3, b.Len())
+
+		insights.err = nil
+		require.NoError(t, b.Flush())
+		require.Equal(t, [][]InsightsEvent{{viewEvent("3"), viewEvent("4"), viewEvent("5")}}, insights.batches)
+	}
+}
+
+func TestInsightsBuffer_flushInterval(t *testing.T) {
+	insights := &stubInsights{}
+	b := NewInsightsBuffer(insights, InsightsBufferOptions{FlushInterval: time.Millisecond})
+	defer b.Close()
+
+	require.NoError(t, b.Add(viewEvent("1")))
+
+	deadline := time.Now().Add(5 * time.Second)
+	for insights.nbBatches() == 0 {
+		require.True(t, time.Now().Before(deadline), "events should be flushed periodically")
+		time.Sleep(time.Millisecond)
+	}
+}
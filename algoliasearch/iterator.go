@@ -0,0 +1,91 @@
+package algoliasearch
+
+import "context"
+
+// defaultIteratorHitsPerPage is the number of items retrieved by each request
+// of the paginated iterators when no page size is given.
+const defaultIteratorHitsPerPage = 1000
+
+// IteratorOptions controls how the paginated iterators, such as RuleIterator
+// or UserIDIterator, retrieve their pages.
+type IteratorOptions struct {
+	// HitsPerPage is the number of items retrieved by each request. Defaults
+	// to 1000.
+	HitsPerPage int
+
+	// RequestOptions are the extra RequestOptions used for each request.
+	RequestOptions *RequestOptions
+}
+
+func (o IteratorOptions) hitsPerPage() int {
+	if o.HitsPerPage <= 0 {
+		return defaultIteratorHitsPerPage
+	}
+	return o.HitsPerPage
+}
+
+// pageLoader loads the page `page` of `hitsPerPage` items, stores its items
+// and returns how many of them were loaded and whether this page is the last
+// one.
+type pageLoader func(page, hitsPerPage int) (nbItems int, lastPage bool, err error)
+
+// paginator walks the pages loaded by a pageLoader. It is shared by all the
+// paginated iterators, which only have to store and return the items of the
+// current page.
+type paginator struct {
+	ctx         context.Context
+	load        pageLoader
+	noMoreErr   error
+	hitsPerPage int
+
+	page     int
+	nbItems  int
+	pos      int
+	lastPage bool
+}
+
+func newPaginator(ctx context.Context, hitsPerPage int, noMoreErr error, load pageLoader) *paginator {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return &paginator{
+		ctx:         ctx,
+		load:        load,
+		noMoreErr:   noMoreErr,
+		hitsPerPage: hitsPerPage,
+		page:        -1,
+	}
+}
+
+// next returns the position, within the current page, of the next item to
+// return. The following pages are loaded as needed and `noMoreErr` is
+// returned once all the items have been returned. If a page cannot be loaded,
+// the error is returned and the same page is loaded again on the next call.
+func (p *paginator) next() (pos int, err error) {
+	for p.pos >= p.nbItems {
+		if p.lastPage {
+			err = p.noMoreErr
+			return
+		}
+
+		if err = p.ctx.Err(); err != nil {
+			return
+		}
+
+		var nbItems int
+		var lastPage bool
+		if nbItems, lastPage, err = p.load(p.page+1, p.hitsPerPage); err != nil {
+			return
+		}
+
+		p.page++
+		p.pos = 0
+		p.nbItems = nbItems
+		p.lastPage = lastPage || nbItems == 0
+	}
+
+	pos = p.pos
+	p.pos++
+	return
+}
@@ -0,0 +1,172 @@
+package algoliasearch
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var errNoMoreItems = errors.New("No more items")
+
+// itemsPaginator returns a paginator over `nbItems` integers, using the
+// number of loaded items to detect the last page, along with the slice of
+// the items of the current page and the list of the loaded pages.
+func itemsPaginator(ctx context.Context, nbItems, hitsPerPage int) (*paginator, *[]int, *[]int) {
+	var items, loaded []int
+	p := newPaginator(ctx, hitsPerPage, errNoMoreItems, func(page, hitsPerPage int) (int, bool, error) {
+		loaded = append(loaded, page)
+		items = items[:0]
+		for n := page * hitsPerPage; n < nbItems && n < (page+1)*hitsPerPage; n++ {
+			items = append(items, n)
+		}
+		return len(items), len(items) < hitsPerPage, nil
+	})
+	return p, &items, &loaded
+}
+
+func TestPaginator(t *testing.T) {
+	for _, c := range []struct {
+		nbItems, hitsPerPage int
+		expectedPages        []int
+	}{
+		{0, 2, []int{0}},
+		{1, 2, []int{0}},
+		{2, 2, []int{0, 1}},
+		{5, 2, []int{0, 1, 2}},
+	} {
+		p, items, loaded := itemsPaginator(context.Background(), c.nbItems, c.hitsPerPage)
+
+		var found []int
+		for {
+			pos, err := p.next()
+			if err != nil {
+				require.Equal(t, errNoMoreItems, err)
+				break
+			}
+			found = append(found, (*items)[pos])
+		}
+
+		require.Len(t, found, c.nbItems)
+		for n, item := range found {
+			require.Equal(t, n, item)
+		}
+		require.Equal(t, c.expectedPages, *loaded)
+
+		_, err := p.next()
+		require.Equal(t, errNoMoreItems, err, "should keep returning the end-of-iteration error")
+	}
+}
+
+func TestPaginator_retryAfterError(t *testing.T) {
+	var loaded []int
+	fail := true
+	p := newPaginator(context.Background(), 10, errNoMoreItems, func(page, hitsPerPage int) (int, bool, error) {
+		loaded = append(loaded, page)
+		if fail {
+			fail = false
+			return 0, false, errors.New("unreachable host")
+		}
+		return 1, true, nil
+	})
+
+	_, err := p.next()
+	require.EqualError(t, err, "unreachable host")
+
+	pos, err := p.next()
+	require.NoError(t, err)
+	require.Equal(t, 0, pos)
+	require.Equal(t, []int{0, 0}, loaded)
+}
+
+func TestPaginator_cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	p, _, loaded := itemsPaginator(ctx, 5, 2)
+
+	_, err := p.next()
+	require.NoError(t, err)
+	_, err = p.next()
+	require.NoError(t, err)
+
+	cancel()
+	_, err = p.next()
+	require.Equal(t, context.Canceled, err)
+	require.Equal(t, []int{0}, *loaded)
+}
+
+// rulesStubIndex is an Index whose SearchRules method serves `nbRules` rules
+// and records the received parameters. Calling any other method panics.
+type rulesStubIndex struct {
+	Index
+	nbRules int
+	params  []Map
+}
+
+func (i *rulesStubIndex) SearchRulesWithRequestOptions(params Map, opts *RequestOptions) (res SearchRulesRes, err error) {
+	i.params = append(i.params, params)
+
+	page, hitsPerPage := params["page"].(int), params["hitsPerPage"].(int)
+	for n := page * hitsPerPage; n < i.nbRules && n < (page+1)*hitsPerPage; n++ {
+		res.Hits = append(res.Hits, Rule{ObjectID: string('a' + rune(n))})
+	}
+	res.NbHits = i.nbRules
+	res.NbPages = (i.nbRules + hitsPerPage - 1) / hitsPerPage
+	return
+}
+
+func TestRuleIterator_pagination(t *testing.T) {
+	i := &rulesStubIndex{nbRules: 5}
+	it := NewRuleIteratorWithOptions(context.Background(), i, IteratorOptions{HitsPerPage: 2})
+
+	var objectIDs string
+	for {
+		rule, err := it.Next()
+		if err != nil {
+			require.Equal(t, NoMoreRulesErr, err)
+			break
+		}
+		objectIDs += rule.ObjectID
+	}
+
+	require.Equal(t, "abcde", objectIDs)
+	require.Equal(t, []Map{
+		{"query": "", "page": 0, "hitsPerPage": 2},
+		{"query": "", "page": 1, "hitsPerPage": 2},
+		{"query": "", "page": 2, "hitsPerPage": 2},
+	}, i.params)
+}
+
+// userIDsStubClient is a Client whose SearchUserIDs method serves `nbHits`
+// user IDs. Calling any other method panics.
+type userIDsStubClient struct {
+	Client
+	nbHits int
+}
+
+func (c *userIDsStubClient) SearchUserIDsWithRequestOptions(query string, params Map, opts *RequestOptions) (res SearchUserIDRes, err error) {
+	page, hitsPerPage := params["page"].(int), params["hitsPerPage"].(int)
+	for n := page * hitsPerPage; n < c.nbHits && n < (page+1)*hitsPerPage; n++ {
+		res.Hits = append(res.Hits, UserIDHit{ObjectID: query + string('a'+rune(n))})
+	}
+	res.NbHits = c.nbHits
+	return
+}
+
+func TestUserIDSearchIterator(t *testing.T) {
+	params := Map{"clusterName": "c1-test"}
+	it := NewUserIDSearchIteratorWithOptions(context.Background(), &userIDsStubClient{nbHits: 4}, "user-", params, IteratorOptions{HitsPerPage: 2})
+
+	var objectIDs []string
+	for {
+		hit, err := it.Next()
+		if err != nil {
+			require.Equal(t, NoMoreUserIDsErr, err)
+			break
+		}
+		objectIDs = append(objectIDs, hit.ObjectID)
+	}
+
+	require.Equal(t, []string{"user-a", "user-b", "user-c", "user-d"}, objectIDs)
+	require.Equal(t, Map{"clusterName": "c1-test"}, params, "should not modify the given parameters")
+}
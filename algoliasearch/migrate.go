@@ -0,0 +1,360 @@
+package algoliasearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// MigrateIndexOptions controls how `MigrateIndex` migrates an index from one
+// application to another.
+type MigrateIndexOptions struct {
+	// BrowseParams are the parameters used to browse the source index. They
+	// can be used to only migrate a subset of the records.
+	BrowseParams Map
+
+	// Filter, if non-nil, is called for each browsed record. Only the records
+	// for which it returns true are migrated.
+	Filter func(record Object) bool
+
+	// Transform, if non-nil, is called for each record which is about to be
+	// migrated and returns the record to save in the destination index. The
+	// returned record must keep an `objectID`.
+	Transform func(record Object) (Object, error)
+
+	// Workers is the number of batches of records which can be sent
+	// concurrently to the destination index. Defaults to 1.
+	Workers int
+
+	// Cursor, if non-empty, resumes a previous migration from the browse
+	// cursor it last reported (see MigrateIndexRes.Cursor).
+	Cursor string
+
+	// SkipSettings, SkipRules and SkipSynonyms disable the migration of the
+	// settings, Rules and synonyms of the index respectively.
+	SkipSettings bool
+	SkipRules    bool
+	SkipSynonyms bool
+
+	// MigrateReplicas, if set, also migrates the `replicas` setting of the
+	// source index. By default, it is not migrated so that the destination
+	// index does not take over, or create, indices named after the replicas
+	// of the source index.
+	MigrateReplicas bool
+
+	// Progress, if non-nil, is called each time a new page of records has
+	// been sent to the destination index, along with all the pages preceding
+	// it. The reported Cursor can then be used to resume the migration.
+	Progress func(progress MigrateIndexRes)
+
+	// SrcRequestOptions and DstRequestOptions are the extra RequestOptions
+	// used for the calls to the source and destination applications.
+	SrcRequestOptions *RequestOptions
+	DstRequestOptions *RequestOptions
+}
+
+// MigrateIndexRes reports the progress of `MigrateIndex`.
+type MigrateIndexRes struct {
+	NbBrowsed  int
+	NbMigrated int
+	NbRules    int
+	NbSynonyms int
+
+	// Cursor is the browse cursor from which the migration can be resumed:
+	// all the records browsed before it have been sent to the destination
+	// index. It is empty once all the records have been migrated.
+	Cursor string
+}
+
+// migrationPage is a page of records which have been browsed from the source
+// index and which should be sent to the destination index.
+type migrationPage struct {
+	number  int
+	cursor  string
+	records []Object
+}
+
+// MigrateIndex copies the index `srcIndex` of the `src` application into the
+// index `dstIndex` of the `dst` application, which may be a different one
+// (contrary to `Client.CopyIndex`). The settings are migrated first, then the
+// records are browsed and sent by batches, possibly concurrently, and finally
+// the Rules and synonyms of the destination index are replaced by the ones of
+// the source index. It hangs until all the operations have completed on the
+// destination index.
+//
+// If the migration fails or if `ctx` is cancelled, the progress made so far is
+// returned along with the error. Its Cursor can then be used as
+// `options.Cursor` to resume the migration without browsing the
+// already-migrated records again.
+func MigrateIndex(ctx context.Context, src Client, srcIndex string, dst Client, dstIndex string, options MigrateIndexOptions) (res MigrateIndexRes, err error) {
+	srcIdx, ok := src.InitIndex(srcIndex).(*index)
+	if !ok {
+		err = errors.New("MigrateIndex: unsupported source Client implementation")
+		return
+	}
+
+	dstIdx, ok := dst.InitIndex(dstIndex).(*index)
+	if !ok {
+		err = errors.New("MigrateIndex: unsupported destination Client implementation")
+		return
+	}
+
+	m := &migration{
+		ctx:     ctx,
+		src:     srcIdx,
+		dst:     dstIdx,
+		options: options,
+	}
+	m.res.Cursor = options.Cursor
+
+	err = m.run()
+	return m.res, err
+}
+
+// migration holds the state of a running `MigrateIndex` call.
+type migration struct {
+	ctx     context.Context
+	src     *index
+	dst     *index
+	options MigrateIndexOptions
+
+	// The following fields are protected by `mu` while records are migrated.
+	mu         sync.Mutex
+	res        MigrateIndexRes
+	donePages  map[int]string
+	nextPage   int
+	lastTasks  []int
+	firstError error
+}
+
+func (m *migration) run() error {
+	srcOpts, dstOpts := m.options.SrcRequestOptions, m.options.DstRequestOptions
+
+	// The tasks of an index being processed sequentially, only the last tasks
+	// sent to the destination index need to be waited for at the end.
+	var tasks []IndexedTask
+
+	if !m.options.SkipSettings {
+		settings, err := m.src.GetSettingsWithRequestOptions(srcOpts)
+		if err != nil {
+			return err
+		}
+		if !m.options.MigrateReplicas {
+			settings.Replicas = nil
+			settings.Slaves = nil
+		}
+		res, err := m.dst.SetTypedSettingsWithRequestOptions(settings, false, dstOpts)
+		if err != nil {
+			return err
+		}
+		tasks = []IndexedTask{{IndexName: m.dst.name, TaskID: res.TaskID}}
+	}
+
+	if err := m.migrateRecords(); err != nil {
+		return err
+	}
+
+	// As records may have been sent concurrently, the last task of each
+	// worker is waited for.
+	if len(m.lastTasks) > 0 {
+		tasks = nil
+		for _, taskID := range m.lastTasks {
+			tasks = append(tasks, IndexedTask{IndexName: m.dst.name, TaskID: taskID})
+		}
+	}
+
+	if !m.options.SkipRules {
+		rules, err := m.src.allRules(srcOpts)
+		if err != nil {
+			return err
+		}
+		for j := range rules {
+			if !rules[j].Enabled {
+				rules[j].Disable()
+			}
+		}
+		taskID, err := m.dst.replaceRules(rules, dstOpts)
+		if err != nil {
+			return err
+		}
+		m.res.NbRules = len(rules)
+		tasks = []IndexedTask{{IndexName: m.dst.name, TaskID: taskID}}
+	}
+
+	if !m.options.SkipSynonyms {
+		synonyms, err := m.src.allSynonyms(srcOpts)
+		if err != nil {
+			return err
+		}
+		taskID, err := m.dst.replaceSynonyms(synonyms, dstOpts)
+		if err != nil {
+			return err
+		}
+		m.res.NbSynonyms = len(synonyms)
+		tasks = []IndexedTask{{IndexName: m.dst.name, TaskID: taskID}}
+	}
+
+	return m.dst.client.waitTasks(m.ctx, tasks, dstOpts)
+}
+
+// migrateRecords browses the source index from the initial cursor and sends
+// the records to the destination index using the configured number of
+// workers.
+func (m *migration) migrateRecords() error {
+	workers := m.options.Workers
+	if workers <= 0 {
+		workers = 1
+	}
+
+	m.donePages = make(map[int]string)
+	m.lastTasks = make([]int, workers)
+
+	pages := make(chan migrationPage)
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for page := range pages {
+				if m.failed() {
+					continue
+				}
+				if err := m.sendPage(worker, page); err != nil {
+					m.fail(err)
+				}
+			}
+		}(w)
+	}
+
+	err := m.browse(pages)
+	close(pages)
+	wg.Wait()
+
+	if err != nil {
+		return err
+	}
+
+	// Only keep the tasks of the workers which actually sent something.
+	var lastTasks []int
+	for _, taskID := range m.lastTasks {
+		if taskID != 0 {
+			lastTasks = append(lastTasks, taskID)
+		}
+	}
+	m.lastTasks = lastTasks
+
+	return m.firstError
+}
+
+// browse reads the source index page by page and pushes the records to
+// migrate to the `pages` channel until the end of the index is reached, an
+// error occurs or the context is done.
+func (m *migration) browse(pages chan<- migrationPage) error {
+	cursor := m.options.Cursor
+	params := m.options.BrowseParams
+
+	for number := 0; ; number++ {
+		if m.failed() {
+			return nil
+		}
+		if err := m.ctx.Err(); err != nil {
+			return err
+		}
+
+		browseRes, err := m.src.BrowseWithRequestOptions(params, cursor, m.options.SrcRequestOptions)
+		if err != nil {
+			return err
+		}
+
+		page := migrationPage{number: number, cursor: browseRes.Cursor}
+		for _, hit := range browseRes.Hits {
+			record := Object(hit)
+			removeHitMetadata(hit)
+
+			if m.options.Filter != nil && !m.options.Filter(record) {
+				continue
+			}
+
+			if m.options.Transform != nil {
+				if record, err = m.options.Transform(record); err != nil {
+					return fmt.Errorf("cannot transform record: %s", err)
+				}
+			}
+
+			page.records = append(page.records, record)
+		}
+
+		m.mu.Lock()
+		m.res.NbBrowsed += len(browseRes.Hits)
+		m.mu.Unlock()
+
+		select {
+		case pages <- page:
+		case <-m.ctx.Done():
+			return m.ctx.Err()
+		}
+
+		if browseRes.Cursor == "" {
+			return nil
+		}
+		cursor = browseRes.Cursor
+	}
+}
+
+// sendPage saves the records of the given page in the destination index and
+// updates the progress of the migration accordingly.
+func (m *migration) sendPage(worker int, page migrationPage) error {
+	var taskID int
+	if len(page.records) > 0 {
+		res, err := m.dst.UpdateObjectsWithRequestOptions(page.records, m.options.DstRequestOptions)
+		if err != nil {
+			return err
+		}
+		taskID = res.TaskID
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if taskID != 0 {
+		m.lastTasks[worker] = taskID
+	}
+	m.res.NbMigrated += len(page.records)
+
+	// The resume cursor only moves forward once all the preceding pages have
+	// also been sent, as pages may complete out of order.
+	m.donePages[page.number] = page.cursor
+	advanced := false
+	for {
+		cursor, ok := m.donePages[m.nextPage]
+		if !ok {
+			break
+		}
+		delete(m.donePages, m.nextPage)
+		m.res.Cursor = cursor
+		m.nextPage++
+		advanced = true
+	}
+
+	if advanced && m.options.Progress != nil {
+		m.options.Progress(m.res)
+	}
+
+	return nil
+}
+
+func (m *migration) fail(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.firstError == nil {
+		m.firstError = err
+	}
+}
+
+func (m *migration) failed() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.firstError != nil
+}
@@ -0,0 +1,153 @@
+package algoliasearch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigration_sendPageOutOfOrder(t *testing.T) {
+	var cursors []string
+	m := &migration{
+		options: MigrateIndexOptions{
+			Progress: func(progress MigrateIndexRes) { cursors = append(cursors, progress.Cursor) },
+		},
+		donePages: make(map[int]string),
+		lastTasks: make([]int, 2),
+	}
+	m.res.Cursor = "initial"
+
+	// Pages without any record are not sent to the destination index, which
+	// lets the cursor tracking be tested without any network call.
+	require.NoError(t, m.sendPage(0, migrationPage{number: 1, cursor: "second"}))
+	require.Equal(t, "initial", m.res.Cursor)
+	require.Empty(t, cursors)
+
+	require.NoError(t, m.sendPage(1, migrationPage{number: 0, cursor: "first"}))
+	require.Equal(t, "second", m.res.Cursor)
+	require.Equal(t, []string{"second"}, cursors)
+
+	require.NoError(t, m.sendPage(0, migrationPage{number: 2, cursor: ""}))
+	require.Equal(t, "", m.res.Cursor)
+	require.Equal(t, []string{"second", ""}, cursors)
+	require.Empty(t, m.donePages)
+}
+
+func TestMigrateIndex_replicas(t *testing.T) {
+	src := NewClient("srcAppID", "apiKey")
+	src.SetHTTPClient(&http.Client{Transport: &routingRoundTripper{responses: map[string]string{
+		"GET /1/indexes/products/settings": `{"hitsPerPage": 10, "replicas": ["products_price_asc"]}`,
+		"POST /1/indexes/products/browse":  `{"hits": []}`,
+	}}})
+
+	for _, migrateReplicas := range []bool{false, true} {
+		rt := &routingRoundTripper{responses: map[string]string{
+			"PUT /1/indexes/products/settings": `{"taskID": 1}`,
+			"GET /1/indexes/products/task/1":   `{"status": "published"}`,
+		}}
+		dst := NewClient("dstAppID", "apiKey")
+		dst.SetHTTPClient(&http.Client{Transport: rt})
+
+		_, err := MigrateIndex(context.Background(), src, "products", dst, "products", MigrateIndexOptions{
+			SkipRules:       true,
+			SkipSynonyms:    true,
+			MigrateReplicas: migrateReplicas,
+		})
+		require.NoError(t, err)
+
+		require.Equal(t, "PUT /1/indexes/products/settings", rt.requests[0])
+		var settings Map
+		require.NoError(t, json.Unmarshal([]byte(rt.bodies[0]), &settings))
+		_, ok := settings["replicas"]
+		require.Equal(t, migrateReplicas, ok, "replicas sent with MigrateReplicas=%t", migrateReplicas)
+	}
+}
+
+func TestMigrateIndex_noRulesNorSynonyms(t *testing.T) {
+	src := NewClient("srcAppID", "apiKey")
+	src.SetHTTPClient(&http.Client{Transport: &routingRoundTripper{responses: map[string]string{
+		"GET /1/indexes/products/settings":         `{"hitsPerPage": 10}`,
+		"POST /1/indexes/products/browse":          `{"hits": []}`,
+		"POST /1/indexes/products/rules/search":    `{"hits": [], "nbHits": 0, "page": 0, "nbPages": 0}`,
+		"POST /1/indexes/products/synonyms/search": `{"hits": [], "nbHits": 0}`,
+	}}})
+
+	rt := &routingRoundTripper{responses: map[string]string{
+		"PUT /1/indexes/products/settings":        `{"taskID": 1}`,
+		"POST /1/indexes/products/rules/clear":    `{"taskID": 2}`,
+		"POST /1/indexes/products/synonyms/clear": `{"taskID": 3}`,
+		"GET /1/indexes/products/task/3":          `{"status": "published"}`,
+	}}
+	dst := NewClient("dstAppID", "apiKey")
+	dst.SetHTTPClient(&http.Client{Transport: rt})
+
+	res, err := MigrateIndex(context.Background(), src, "products", dst, "products", MigrateIndexOptions{})
+	require.NoError(t, err)
+	require.Equal(t, 0, res.NbRules)
+	require.Equal(t, 0, res.NbSynonyms)
+	require.Equal(t, []string{
+		"PUT /1/indexes/products/settings",
+		"POST /1/indexes/products/rules/clear",
+		"POST /1/indexes/products/synonyms/clear",
+		"GET /1/indexes/products/task/3",
+	}, rt.requests)
+	require.Empty(t, rt.bodies[1])
+	require.Empty(t, rt.bodies[2])
+}
+
+func TestMigrateIndex(t *testing.T) {
+	t.Parallel()
+	c, i := initClientAndIndex(t, "TestMigrateIndex")
+	synonyms := addObjectsAndSynonyms(t, i, "TestMigrateIndex")
+	rules := addRules(t, i, "TestMigrateIndex")
+
+	dst := initIndex(t, c, "TestMigrateIndex_dst")
+	defer dst.Delete()
+
+	t.Log("TestMigrateIndex: Migrate the index with a filter and a transformation")
+	res, err := MigrateIndex(context.Background(), c, "TestMigrateIndex", c, "TestMigrateIndex_dst", MigrateIndexOptions{
+		BrowseParams: Map{"hitsPerPage": 2},
+		Workers:      3,
+		Filter: func(record Object) bool {
+			return record["company"] != "Yahoo"
+		},
+		Transform: func(record Object) (Object, error) {
+			record["migrated"] = true
+			return record, nil
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 9, res.NbBrowsed)
+	require.Equal(t, 8, res.NbMigrated)
+	require.Equal(t, len(rules), res.NbRules)
+	require.Equal(t, len(synonyms), res.NbSynonyms)
+	require.Empty(t, res.Cursor)
+
+	t.Log("TestMigrateIndex: Compare both indices")
+	{
+		settings, err := i.GetSettings()
+		require.NoError(t, err)
+		dstSettings, err := dst.GetSettings()
+		require.NoError(t, err)
+		settingsAreEqual(t, settings, dstSettings)
+
+		dstSynonyms, err := dst.SearchSynonyms("", nil, 0, 1000)
+		require.NoError(t, err)
+		require.True(t, synonymSlicesAreEqual(synonyms, dstSynonyms))
+
+		dstRules, err := dst.SearchRules(Map{"query": ""})
+		require.NoError(t, err)
+		require.True(t, ruleSlicesAreEqual(rules, dstRules.Hits))
+
+		res, err := dst.Search("", nil)
+		require.NoError(t, err)
+		require.Equal(t, 8, res.NbHits)
+		for _, hit := range res.Hits {
+			require.NotEqual(t, "Yahoo", hit["company"])
+			require.Equal(t, true, hit["migrated"])
+		}
+	}
+}
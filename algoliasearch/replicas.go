@@ -0,0 +1,211 @@
+package algoliasearch
+
+// defaultRanking is the ranking the engine uses for the indices which have
+// no explicit `ranking` setting.
+var defaultRanking = []string{"typo", "geo", "words", "filters", "proximity", "attribute", "exact", "custom"}
+
+func (i *index) SyncReplicas(config ReplicasConfig) (res SyncReplicasRes, err error) {
+	return i.SyncReplicasWithRequestOptions(config, nil)
+}
+
+func (i *index) SyncReplicasWithRequestOptions(config ReplicasConfig, opts *RequestOptions) (res SyncReplicasRes, err error) {
+	if err = checkReplicasConfig(i.name, config); err != nil {
+		return
+	}
+
+	primary, err := i.GetSettingsWithRequestOptions(opts)
+	if err != nil {
+		return
+	}
+
+	res.Drift.Missing, res.Drift.Extra = diffReplicaNames(primary, config)
+
+	// The replicas are attached first, so that the missing ones get created
+	// by the engine before their settings are compared.
+	if len(res.Drift.Missing) > 0 || len(res.Drift.Extra) > 0 {
+		names := make([]string, len(config.Replicas))
+		for j, replica := range config.Replicas {
+			names[j] = replica.Name
+		}
+
+		var setRes UpdateTaskRes
+		if setRes, err = i.SetSettingsWithRequestOptions(Map{"replicas": names}, opts); err != nil {
+			return
+		}
+		if err = i.WaitTaskWithRequestOptions(setRes.TaskID, opts); err != nil {
+			return
+		}
+	}
+
+	var tasks []IndexedTask
+
+	if config.DeleteRemoved {
+		for _, name := range res.Drift.Extra {
+			var deleteRes DeleteTaskRes
+			if deleteRes, err = i.client.DeleteIndexWithRequestOptions(name, opts); err != nil {
+				return
+			}
+			tasks = append(tasks, IndexedTask{IndexName: name, TaskID: deleteRes.TaskID})
+			res.Deleted = append(res.Deleted, name)
+		}
+	}
+
+	primaryMap := primary.ToMap()
+	for _, replica := range config.Replicas {
+		var diff SettingsDiff
+		if diff, err = i.replicaSettingsDiff(primaryMap, replica, config, opts); err != nil {
+			return
+		}
+		if len(diff) == 0 {
+			continue
+		}
+
+		var setRes UpdateTaskRes
+		if setRes, err = i.client.InitIndex(replica.Name).SetSettingsWithRequestOptions(diff.ToMap(), opts); err != nil {
+			return
+		}
+		tasks = append(tasks, IndexedTask{IndexName: replica.Name, TaskID: setRes.TaskID})
+
+		if res.Drift.Settings == nil {
+			res.Drift.Settings = make(map[string]SettingsDiff)
+		}
+		res.Drift.Settings[replica.Name] = diff
+	}
+
+	err = i.client.waitTasks(opts.context(), tasks, opts)
+	return
+}
+
+func (i *index) GetReplicasDrift(config ReplicasConfig) (drift ReplicasDrift, err error) {
+	return i.GetReplicasDriftWithRequestOptions(config, nil)
+}
+
+func (i *index) GetReplicasDriftWithRequestOptions(config ReplicasConfig, opts *RequestOptions) (drift ReplicasDrift, err error) {
+	if err = checkReplicasConfig(i.name, config); err != nil {
+		return
+	}
+
+	primary, err := i.GetSettingsWithRequestOptions(opts)
+	if err != nil {
+		return
+	}
+
+	drift.Missing, drift.Extra = diffReplicaNames(primary, config)
+
+	missing := make(map[string]bool)
+	for _, name := range drift.Missing {
+		missing[name] = true
+	}
+
+	primaryMap := primary.ToMap()
+	for _, replica := range config.Replicas {
+		if missing[replica.Name] {
+			continue
+		}
+
+		var diff SettingsDiff
+		if diff, err = i.replicaSettingsDiff(primaryMap, replica, config, opts); err != nil {
+			return
+		}
+		if len(diff) == 0 {
+			continue
+		}
+
+		if drift.Settings == nil {
+			drift.Settings = make(map[string]SettingsDiff)
+		}
+		drift.Settings[replica.Name] = diff
+	}
+
+	return
+}
+
+// replicaSettingsDiff retrieves the current settings of the given replica and
+// returns the changes needed to reach its expected settings.
+func (i *index) replicaSettingsDiff(primary Map, replica Replica, config ReplicasConfig, opts *RequestOptions) (diff SettingsDiff, err error) {
+	current, err := i.client.InitIndex(replica.Name).GetSettingsWithRequestOptions(opts)
+	if err != nil {
+		return
+	}
+
+	diff = diffSettingMaps(current.ToMap(), expectedReplicaSettings(primary, replica, config.PropagatedSettings))
+	return
+}
+
+// expectedReplicaSettings returns the settings a replica should have given
+// the settings of its `primary`: the propagated settings of the primary,
+// its ranking preceded by the sort criteria of the replica, if any, and
+// finally the extra settings of the replica. The propagated settings which
+// are not set on the primary are expected to be unset, hence nil, on the
+// replica as well.
+func expectedReplicaSettings(primary Map, replica Replica, propagated []string) Map {
+	expected := make(Map)
+
+	for _, k := range propagated {
+		expected[k] = primary[k]
+	}
+
+	if len(replica.Sort) > 0 {
+		ranking, ok := primary["ranking"].([]string)
+		if !ok {
+			ranking = defaultRanking
+		}
+		expected["ranking"] = sortedRanking(replica.Sort, ranking)
+	}
+
+	if replica.Settings != nil {
+		for k, v := range replica.Settings.ToMap() {
+			expected[k] = v
+		}
+	}
+
+	return expected
+}
+
+// sortedRanking returns the `sort` criteria followed by the criteria of
+// `ranking` which do not sort on the same attributes.
+func sortedRanking(sort, ranking []string) []string {
+	sorted := make(map[string]bool)
+	for _, criterion := range sort {
+		sorted[sortCriterionAttribute(criterion)] = true
+	}
+
+	res := append([]string{}, sort...)
+	for _, criterion := range ranking {
+		if attribute := sortCriterionAttribute(criterion); attribute == "" || !sorted[attribute] {
+			res = append(res, criterion)
+		}
+	}
+	return res
+}
+
+// diffReplicaNames returns the replicas declared by `config` which are not
+// replicas of the `primary` and the replicas of the primary which are not
+// declared.
+func diffReplicaNames(primary Settings, config ReplicasConfig) (missing, extra []string) {
+	current := primary.Replicas
+	if current == nil {
+		current = primary.Slaves
+	}
+
+	currentNames := make(map[string]bool)
+	for _, name := range current {
+		currentNames[name] = true
+	}
+
+	declaredNames := make(map[string]bool)
+	for _, replica := range config.Replicas {
+		declaredNames[replica.Name] = true
+		if !currentNames[replica.Name] {
+			missing = append(missing, replica.Name)
+		}
+	}
+
+	for _, name := range current {
+		if !declaredNames[name] {
+			extra = append(extra, name)
+		}
+	}
+
+	return
+}
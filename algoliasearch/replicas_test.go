@@ -0,0 +1,143 @@
+package algoliasearch
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckReplicasConfig(t *testing.T) {
+	valid := ReplicasConfig{
+		Replicas: []Replica{
+			{Name: "products_price_asc", Sort: []string{"asc(price)"}},
+			{Name: "products_en", Settings: &Settings{QueryLanguages: []string{"en"}}},
+		},
+		PropagatedSettings: []string{"searchableAttributes"},
+	}
+	require.NoError(t, checkReplicasConfig("products", valid))
+
+	for _, config := range []ReplicasConfig{
+		{Replicas: []Replica{{}}},
+		{Replicas: []Replica{{Name: "products"}}},
+		{Replicas: []Replica{{Name: "a"}, {Name: "a"}}},
+		{Replicas: []Replica{{Name: "a", Sort: []string{"price"}}}},
+		{Replicas: []Replica{{Name: "a", Sort: []string{"asc()"}}}},
+		{Replicas: []Replica{{Name: "a", Settings: &Settings{Replicas: []string{"b"}}}}},
+		{Replicas: []Replica{{Name: "a", Sort: []string{"asc(price)"}, Settings: &Settings{Ranking: []string{"typo"}}}}},
+		{Replicas: []Replica{{Name: "a", Settings: &Settings{Distinct: "1"}}}},
+		{PropagatedSettings: []string{"ranking"}},
+	} {
+		require.Error(t, checkReplicasConfig("products", config), "%#v", config)
+	}
+
+	err := checkReplicasConfig("products", ReplicasConfig{PropagatedSettings: []string{"searchableAttribute"}})
+	require.EqualError(t, err, "unknown propagated setting `searchableAttribute`: did you mean `searchableAttributes`?")
+}
+
+func TestExpectedReplicaSettings(t *testing.T) {
+	primary := Map{
+		"ranking":              []string{"typo", "words", "desc(price)", "custom"},
+		"searchableAttributes": []string{"name", "brand"},
+		"hitsPerPage":          20,
+	}
+
+	require.Equal(t, Map{
+		"ranking":               []string{"asc(price)", "typo", "words", "custom"},
+		"searchableAttributes":  []string{"name", "brand"},
+		"hitsPerPage":           10,
+		"attributesForFaceting": nil,
+	}, expectedReplicaSettings(primary, Replica{
+		Name:     "products_price_asc",
+		Sort:     []string{"asc(price)"},
+		Settings: &Settings{HitsPerPage: Int(10)},
+	}, []string{"searchableAttributes", "hitsPerPage", "attributesForFaceting"}))
+
+	require.Equal(t, Map{
+		"ranking":              append([]string{"desc(date)"}, defaultRanking...),
+		"searchableAttributes": nil,
+	}, expectedReplicaSettings(Map{}, Replica{
+		Name: "products_date_desc",
+		Sort: []string{"desc(date)"},
+	}, []string{"searchableAttributes"}))
+}
+
+func TestSyncReplicas(t *testing.T) {
+	rt := &routingRoundTripper{responses: map[string]string{
+		"GET /1/indexes/products/settings":           `{"ranking": ["typo", "custom"], "searchableAttributes": ["name"], "replicas": ["products_price_asc", "products_old"]}`,
+		"GET /1/indexes/products_price_asc/settings": `{"ranking": ["asc(price)", "typo", "custom"], "searchableAttributes": ["brand"], "attributesForFaceting": ["brand"], "primary": "products"}`,
+		"GET /1/indexes/products_date_desc/settings": `{"ranking": ["typo", "custom"], "searchableAttributes": ["name"], "primary": "products"}`,
+		"PUT /1/indexes/products/settings":           `{"taskID": 1}`,
+		"PUT /1/indexes/products_price_asc/settings": `{"taskID": 2}`,
+		"PUT /1/indexes/products_date_desc/settings": `{"taskID": 3}`,
+		"DELETE /1/indexes/products_old":             `{"taskID": 4}`,
+		"GET /1/indexes/products/task/1":             `{"status": "published"}`,
+		"GET /1/indexes/products_price_asc/task/2":   `{"status": "published"}`,
+		"GET /1/indexes/products_date_desc/task/3":   `{"status": "published"}`,
+		"GET /1/indexes/products_old/task/4":         `{"status": "published"}`,
+	}}
+	c := NewClient("appID", "apiKey")
+	c.SetHTTPClient(&http.Client{Transport: rt})
+	i := c.InitIndex("products")
+
+	config := ReplicasConfig{
+		Replicas: []Replica{
+			{Name: "products_price_asc", Sort: []string{"asc(price)"}},
+			{Name: "products_date_desc", Sort: []string{"desc(date)"}},
+		},
+		PropagatedSettings: []string{"searchableAttributes", "attributesForFaceting"},
+		DeleteRemoved:      true,
+	}
+
+	t.Log("TestSyncReplicas: Report the drift without changing anything")
+	{
+		drift, err := i.GetReplicasDrift(config)
+		require.NoError(t, err)
+		require.Equal(t, ReplicasDrift{
+			Missing: []string{"products_date_desc"},
+			Extra:   []string{"products_old"},
+			Settings: map[string]SettingsDiff{
+				"products_price_asc": {
+					{Setting: "attributesForFaceting", Before: []string{"brand"}, After: nil},
+					{Setting: "searchableAttributes", Before: []string{"brand"}, After: []string{"name"}},
+				},
+			},
+		}, drift)
+		require.Equal(t, ""+
+			"+ replica products_date_desc\n"+
+			"- replica products_old\n"+
+			"~ replica products_price_asc attributesForFaceting: [\"brand\"] -> <unset>\n"+
+			"~ replica products_price_asc searchableAttributes: [\"brand\"] -> [\"name\"]", drift.String())
+		require.Equal(t, []string{
+			"GET /1/indexes/products/settings",
+			"GET /1/indexes/products_price_asc/settings",
+		}, rt.requests)
+	}
+
+	t.Log("TestSyncReplicas: Attach, detach and update the replicas")
+	{
+		rt.requests, rt.bodies = nil, nil
+		res, err := i.SyncReplicas(config)
+		require.NoError(t, err)
+		require.Equal(t, []string{"products_old"}, res.Deleted)
+		require.Equal(t, SettingsDiff{
+			{Setting: "ranking", Before: []string{"typo", "custom"}, After: []string{"desc(date)", "typo", "custom"}},
+		}, res.Drift.Settings["products_date_desc"])
+		require.Len(t, res.Drift.Settings, 2)
+
+		require.Equal(t, "PUT /1/indexes/products/settings", rt.requests[1])
+		require.JSONEq(t, `{"replicas": ["products_price_asc", "products_date_desc"]}`, rt.bodies[1])
+
+		sets := make(map[string]string)
+		for j, request := range rt.requests {
+			if request != "PUT /1/indexes/products/settings" && request[:3] == "PUT" {
+				sets[request] = rt.bodies[j]
+			}
+		}
+		require.JSONEq(t, `{"attributesForFaceting": null, "searchableAttributes": ["name"]}`, sets["PUT /1/indexes/products_price_asc/settings"])
+		require.JSONEq(t, `{"ranking": ["desc(date)", "typo", "custom"]}`, sets["PUT /1/indexes/products_date_desc/settings"])
+
+		require.Contains(t, rt.requests, "DELETE /1/indexes/products_old")
+		require.Contains(t, rt.requests, "GET /1/indexes/products_old/task/4")
+	}
+}
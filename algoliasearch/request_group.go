@@ -0,0 +1,63 @@
+package algoliasearch
+
+import "sync"
+
+// requestGroup deduplicates identical in-flight requests: while a request is
+// being performed, the callers issuing the same request wait for it to
+// complete and share its raw response instead of reaching the servers
+// themselves.
+type requestGroup struct {
+	mu    sync.Mutex
+	calls map[string]*inflightRequest
+}
+
+type inflightRequest struct {
+	wg   sync.WaitGroup
+	res  []byte
+	err  error
+	dups int
+}
+
+func newRequestGroup() *requestGroup {
+	return &requestGroup{calls: make(map[string]*inflightRequest)}
+}
+
+// do calls `fn` and returns its results, unless a request with the same `key`
+// is already in flight, in which case its results are returned once it
+// completes. The returned response must not be modified as it may be shared
+// by several callers.
+func (g *requestGroup) do(key string, fn func() ([]byte, error)) ([]byte, error) {
+	g.mu.Lock()
+	if call, ok := g.calls[key]; ok {
+		call.dups++
+		g.mu.Unlock()
+		call.wg.Wait()
+		return call.res, call.err
+	}
+
+	call := &inflightRequest{}
+	call.wg.Add(1)
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	defer func() {
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		call.wg.Done()
+	}()
+
+	call.res, call.err = fn()
+	return call.res, call.err
+}
+
+// waiting returns the number of callers currently waiting for the in-flight
+// request identified by `key`.
+func (g *requestGroup) waiting(key string) int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if call, ok := g.calls[key]; ok {
+		return call.dups
+	}
+	return 0
+}
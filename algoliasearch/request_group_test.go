@@ -0,0 +1,122 @@
+package algoliasearch
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// waitForDuplicates blocks until `n` callers are waiting for the in-flight
+// request identified by `key`.
+func waitForDuplicates(t *testing.T, g *requestGroup, key string, n int) {
+	deadline := time.Now().Add(5 * time.Second)
+	for g.waiting(key) < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("%d callers should be waiting for %s but got %d", n, key, g.waiting(key))
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestRequestGroup(t *testing.T) {
+	g := newRequestGroup()
+	release := make(chan struct{})
+	var nbCalls int32
+
+	fn := func() ([]byte, error) {
+		atomic.AddInt32(&nbCalls, 1)
+		<-release
+		return []byte("response"), errors.New("error")
+	}
+
+	var wg sync.WaitGroup
+	results := make([]string, 5)
+	errs := make([]error, 5)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		res, err := g.do("key", fn)
+		results[0], errs[0] = string(res), err
+	}()
+
+	// Wait for the first call to be in flight before issuing the others.
+	for atomic.LoadInt32(&nbCalls) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	for n := 1; n < 5; n++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			res, err := g.do("key", fn)
+			results[n], errs[n] = string(res), err
+		}(n)
+	}
+
+	waitForDuplicates(t, g, "key", 4)
+	close(release)
+	wg.Wait()
+
+	require.Equal(t, int32(1), atomic.LoadInt32(&nbCalls))
+	for n := range results {
+		require.Equal(t, "response", results[n])
+		require.EqualError(t, errs[n], "error")
+	}
+
+	// Once completed, the request is performed again.
+	res, err := g.do("key", func() ([]byte, error) { return []byte("new response"), nil })
+	require.NoError(t, err)
+	require.Equal(t, "new response", string(res))
+}
+
+func TestClient_requestDeduplication(t *testing.T) {
+	release := make(chan struct{})
+	var nbRequests int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&nbRequests, 1)
+		<-release
+		w.Write([]byte(`{"hits":[{"objectID":"1"}],"nbHits":1}`))
+	}))
+	defer server.Close()
+
+	c := NewClientWithHosts("appID", "apiKey", []string{strings.TrimPrefix(server.URL, "https://")})
+	c.SetHTTPClient(server.Client())
+	c.SetRequestDeduplication(true)
+	i := c.InitIndex("products")
+
+	key, err := c.(*client).transport.cacheKey("POST", "/1/indexes/products/query", Map{"params": encodeMap(Map{"query": "phone"})}, nil)
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	results := make([]QueryRes, 3)
+	errs := make([]error, 3)
+	for n := range results {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			results[n], errs[n] = i.Search("phone", nil)
+		}(n)
+	}
+
+	waitForDuplicates(t, c.(*client).requestGroup, key, 2)
+	close(release)
+	wg.Wait()
+
+	require.Equal(t, int32(1), atomic.LoadInt32(&nbRequests))
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+
+	// Each caller gets its own decoded copy of the response.
+	results[0].Hits[0]["objectID"] = "modified"
+	require.Equal(t, "1", results[1].Hits[0]["objectID"])
+	require.Equal(t, "1", results[2].Hits[0]["objectID"])
+}
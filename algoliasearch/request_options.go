@@ -1,7 +1,44 @@
 package algoliasearch
 
+import "context"
+
 type RequestOptions struct {
 	ForwardedFor   string
 	ExtraHeaders   map[string]string
 	ExtraUrlParams map[string]string
+
+	// BypassCache, if set, forces the request to reach the Algolia servers
+	// even if a ResponseCache is set on the Client. The response is still
+	// stored in the cache.
+	BypassCache bool
+
+	// Context, if non-nil, bounds the whole request, including its retries on
+	// the other hosts: once it is done, the request is interrupted and its
+	// error is returned. It can be used to cancel a request or to set an
+	// overall timeout, on top of the per-host timeouts of the Client.
+	Context context.Context
+
+	// SnapshotAuthor is recorded as the Author of the SettingsSnapshot taken
+	// by `SetSettings`, if a SettingsSnapshotStore is set on the Client.
+	SnapshotAuthor string
+}
+
+// context returns the context of the RequestOptions, which defaults to
+// context.Background() if the RequestOptions or their Context are nil.
+func (opts *RequestOptions) context() context.Context {
+	if opts == nil || opts.Context == nil {
+		return context.Background()
+	}
+	return opts.Context
+}
+
+// withContext returns a copy of the RequestOptions, which may be nil, whose
+// Context is `ctx`.
+func (opts *RequestOptions) withContext(ctx context.Context) *RequestOptions {
+	var copy RequestOptions
+	if opts != nil {
+		copy = *opts
+	}
+	copy.Context = ctx
+	return &copy
 }
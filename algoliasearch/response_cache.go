@@ -0,0 +1,188 @@
+package algoliasearch
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ResponseCache is the store used by a Client, once set with
+// `Client.SetResponseCache`, to cache the raw responses of the `Search`,
+// `MultipleQueries` and `SearchForFacetValues` methods. Implementations must
+// be safe for concurrent use. LRUResponseCache is the default in-memory
+// implementation.
+type ResponseCache interface {
+	// Get returns the response stored for `key`, if any.
+	Get(key string) (response []byte, ok bool)
+
+	// Set stores the `response` for `key`. The store is free to evict it at
+	// any time.
+	Set(key string, response []byte)
+}
+
+// LRUResponseCacheOptions controls the limits of an LRUResponseCache. A zero
+// value means that the corresponding limit is disabled.
+type LRUResponseCacheOptions struct {
+	// MaxEntries is the maximum number of responses kept in the cache.
+	MaxEntries int
+
+	// MaxBytes is the maximum total size of the responses kept in the cache.
+	// Responses larger than MaxBytes are never cached.
+	MaxBytes int
+
+	// TTL is the duration after which a cached response expires.
+	TTL time.Duration
+}
+
+// LRUResponseCache is an in-memory ResponseCache which evicts the least
+// recently used responses once its limits are reached.
+type LRUResponseCache struct {
+	mu      sync.Mutex
+	options LRUResponseCacheOptions
+	lru     *list.List
+	entries map[string]*list.Element
+	size    int
+	now     func() time.Time
+}
+
+type lruResponseCacheEntry struct {
+	key       string
+	response  []byte
+	expiresAt time.Time
+}
+
+// NewLRUResponseCache returns an empty LRUResponseCache bounded by the given
+// `options`.
+func NewLRUResponseCache(options LRUResponseCacheOptions) *LRUResponseCache {
+	return &LRUResponseCache{
+		options: options,
+		lru:     list.New(),
+		entries: make(map[string]*list.Element),
+		now:     time.Now,
+	}
+}
+
+func (c *LRUResponseCache) Get(key string) (response []byte, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := elem.Value.(*lruResponseCacheEntry)
+	if !entry.expiresAt.IsZero() && !c.now().Before(entry.expiresAt) {
+		c.remove(elem)
+		return nil, false
+	}
+
+	c.lru.MoveToFront(elem)
+	return entry.response, true
+}
+
+func (c *LRUResponseCache) Set(key string, response []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+	}
+
+	if c.options.MaxBytes > 0 && len(response) > c.options.MaxBytes {
+		return
+	}
+
+	entry := &lruResponseCacheEntry{key: key, response: response}
+	if c.options.TTL > 0 {
+		entry.expiresAt = c.now().Add(c.options.TTL)
+	}
+
+	c.entries[key] = c.lru.PushFront(entry)
+	c.size += len(response)
+
+	for (c.options.MaxEntries > 0 && c.lru.Len() > c.options.MaxEntries) ||
+		(c.options.MaxBytes > 0 && c.size > c.options.MaxBytes) {
+		c.remove(c.lru.Back())
+	}
+}
+
+// Len returns the number of responses currently in the cache, including the
+// expired ones which have not been evicted yet.
+func (c *LRUResponseCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// Purge removes all the responses from the cache.
+func (c *LRUResponseCache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lru.Init()
+	c.entries = make(map[string]*list.Element)
+	c.size = 0
+}
+
+func (c *LRUResponseCache) remove(elem *list.Element) {
+	entry := c.lru.Remove(elem).(*lruResponseCacheEntry)
+	delete(c.entries, entry.key)
+	c.size -= len(entry.response)
+}
+
+// cacheKey returns the key identifying the response of the given request:
+// two requests with the same key share the same method, path, body and
+// headers, hence the same credentials.
+func (t *Transport) cacheKey(method, path string, body interface{}, opts *RequestOptions) (string, error) {
+	encodedBody, err := json.Marshal(body)
+	if err != nil {
+		return "", err
+	}
+
+	headers := make(map[string]string)
+	for k, v := range t.headers {
+		headers[k] = v
+	}
+
+	var urlParams map[string]string
+	if opts != nil {
+		for k, v := range opts.ExtraHeaders {
+			headers[k] = v
+		}
+		if opts.ForwardedFor != "" {
+			headers["X-Forwarded-For"] = opts.ForwardedFor
+		}
+		urlParams = opts.ExtraUrlParams
+	}
+
+	h := sha256.New()
+	write := func(s string) {
+		// The length prefix prevents two different sequences of strings from
+		// producing the same key.
+		fmt.Fprintf(h, "%d:%s", len(s), s)
+	}
+
+	write(method)
+	write(path)
+	write(string(encodedBody))
+	for _, m := range []map[string]string{headers, urlParams} {
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		write(strconv.Itoa(len(keys)))
+		for _, k := range keys {
+			write(k)
+			write(m[k])
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
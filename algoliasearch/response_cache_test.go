@@ -0,0 +1,126 @@
+package algoliasearch
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLRUResponseCache_maxEntries(t *testing.T) {
+	c := NewLRUResponseCache(LRUResponseCacheOptions{MaxEntries: 2})
+
+	c.Set("a", []byte("1"))
+	c.Set("b", []byte("2"))
+	_, ok := c.Get("a")
+	require.True(t, ok)
+
+	// As `a` was used more recently, `b` is evicted.
+	c.Set("c", []byte("3"))
+	require.Equal(t, 2, c.Len())
+	_, ok = c.Get("b")
+	require.False(t, ok)
+
+	r, ok := c.Get("a")
+	require.True(t, ok)
+	require.Equal(t, []byte("1"), r)
+
+	c.Purge()
+	require.Equal(t, 0, c.Len())
+}
+
+func TestLRUResponseCache_maxBytes(t *testing.T) {
+	c := NewLRUResponseCache(LRUResponseCacheOptions{MaxBytes: 10})
+
+	c.Set("a", []byte("12345"))
+	c.Set("b", []byte("12345"))
+	require.Equal(t, 2, c.Len())
+
+	c.Set("c", []byte("1"))
+	_, ok := c.Get("a")
+	require.False(t, ok, "oldest entry should be evicted once the size limit is exceeded")
+
+	c.Set("d", []byte("12345678901"))
+	_, ok = c.Get("d")
+	require.False(t, ok, "entries larger than the limit should never be cached")
+	require.Equal(t, 2, c.Len())
+}
+
+func TestLRUResponseCache_ttl(t *testing.T) {
+	now := time.Now()
+	c := NewLRUResponseCache(LRUResponseCacheOptions{TTL: time.Minute})
+	c.now = func() time.Time { return now }
+
+	c.Set("a", []byte("1"))
+	_, ok := c.Get("a")
+	require.True(t, ok)
+
+	now = now.Add(time.Minute)
+	_, ok = c.Get("a")
+	require.False(t, ok)
+	require.Equal(t, 0, c.Len())
+}
+
+func TestTransport_cacheKey(t *testing.T) {
+	tr := NewTransport("appID", "apiKey")
+	body := Map{"params": "query=phone"}
+
+	key := func(path string, body interface{}, opts *RequestOptions) string {
+		k, err := tr.cacheKey("POST", path, body, opts)
+		require.NoError(t, err)
+		return k
+	}
+
+	ref := key("/1/indexes/products/query", body, nil)
+	require.Equal(t, ref, key("/1/indexes/products/query", Map{"params": "query=phone"}, nil))
+	require.Equal(t, ref, key("/1/indexes/products/query", body, &RequestOptions{BypassCache: true}))
+
+	require.NotEqual(t, ref, key("/1/indexes/other/query", body, nil))
+	require.NotEqual(t, ref, key("/1/indexes/products/query", Map{"params": "query=laptop"}, nil))
+	require.NotEqual(t, ref, key("/1/indexes/products/query", body, &RequestOptions{ForwardedFor: "1.2.3.4"}))
+	require.NotEqual(t, ref, key("/1/indexes/products/query", body, &RequestOptions{
+		ExtraHeaders: map[string]string{"X-Algolia-UserToken": "user-1"},
+	}))
+}
+
+func TestClient_responseCache(t *testing.T) {
+	var nbRequests int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&nbRequests, 1)
+		w.Write([]byte(`{"hits":[{"objectID":"1"}],"nbHits":1}`))
+	}))
+	defer server.Close()
+
+	c := NewClientWithHosts("appID", "apiKey", []string{strings.TrimPrefix(server.URL, "https://")})
+	c.SetHTTPClient(server.Client())
+	c.SetResponseCache(NewLRUResponseCache(LRUResponseCacheOptions{MaxEntries: 10}))
+	i := c.InitIndex("products")
+
+	for n := 0; n < 3; n++ {
+		res, err := i.Search("phone", nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, res.NbHits)
+
+		// Each caller must get its own copy of the response.
+		res.Hits[0]["objectID"] = "modified"
+	}
+	require.Equal(t, int32(1), atomic.LoadInt32(&nbRequests))
+
+	res, err := i.SearchWithRequestOptions("phone", nil, &RequestOptions{BypassCache: true})
+	require.NoError(t, err)
+	require.Equal(t, "1", res.Hits[0]["objectID"])
+	require.Equal(t, int32(2), atomic.LoadInt32(&nbRequests))
+
+	_, err = i.Search("laptop", nil)
+	require.NoError(t, err)
+	require.Equal(t, int32(3), atomic.LoadInt32(&nbRequests))
+
+	c.SetResponseCache(nil)
+	_, err = i.Search("phone", nil)
+	require.NoError(t, err)
+	require.Equal(t, int32(4), atomic.LoadInt32(&nbRequests))
+}
@@ -107,6 +107,7 @@ func NewRetryStrategy(appID string, providedHosts []string) *retryStrategy {
 		)...)
 	}
 	allHosts = append(allHosts, &statefulHost{host: "analytics.algolia.com", lastUpdate: now, accept: call.IsAnalytics})
+	allHosts = append(allHosts, &statefulHost{host: "insights.algolia.io", lastUpdate: now, accept: call.IsInsights})
 
 	return &retryStrategy{
 		hosts:            allHosts,
@@ -129,7 +130,7 @@ func (s *retryStrategy) GetTryableHosts(k call.Kind) []TryableHost {
 		baseTimeout = s.readTimeout
 	case call.Write:
 		baseTimeout = s.writeTimeout
-	case call.Analytics:
+	case call.Analytics, call.Insights:
 		baseTimeout = s.analyticsTimeout
 	default:
 		return nil
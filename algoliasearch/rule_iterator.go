@@ -1,25 +1,39 @@
 package algoliasearch
 
+import "context"
+
 // RuleIterator is the exposed structure to iterate over all the rules of
 // an index.
 type RuleIterator struct {
-	index       Index
-	rules       []Rule
-	hitsPerPage int
-	page        int
-	pos         int
+	paginator *paginator
+	rules     []Rule
 }
 
 // NewRuleIterator returns a new RuleIterator that will iterate over all
 // the rules of the declared index.
 func NewRuleIterator(index Index) *RuleIterator {
-	return &RuleIterator{
-		index:       index,
-		rules:       nil,
-		hitsPerPage: 1000,
-		page:        -1,
-		pos:         -1,
-	}
+	return NewRuleIteratorWithOptions(context.Background(), index, IteratorOptions{})
+}
+
+// NewRuleIteratorWithOptions is the same as NewRuleIterator but the iteration
+// stops as soon as `ctx` is done and the pages are retrieved according to the
+// given IteratorOptions.
+func NewRuleIteratorWithOptions(ctx context.Context, index Index, options IteratorOptions) *RuleIterator {
+	it := &RuleIterator{}
+	it.paginator = newPaginator(ctx, options.hitsPerPage(), NoMoreRulesErr, func(page, hitsPerPage int) (int, bool, error) {
+		res, err := index.SearchRulesWithRequestOptions(Map{
+			"query":       "",
+			"page":        page,
+			"hitsPerPage": hitsPerPage,
+		}, options.RequestOptions)
+		if err != nil {
+			return 0, false, err
+		}
+
+		it.rules = res.Hits
+		return len(res.Hits), page+1 >= res.NbPages, nil
+	})
+	return it
 }
 
 // Next returns iterate to the next rule of the underlying index. Every call
@@ -28,38 +42,12 @@ func NewRuleIterator(index Index) *RuleIterator {
 // rules have been retrieved. If the error is of a different type, it means
 // that the iteration could not have been done correctly.
 func (it *RuleIterator) Next() (*Rule, error) {
-	if it.rules == nil || it.pos >= len(it.rules) {
-		if err := it.loadNextPage(); err != nil {
-			it.reset()
-			return nil, err
-		}
-	}
-
-	it.pos++
-	if it.pos >= len(it.rules) {
-		return nil, NoMoreRulesErr
+	pos, err := it.paginator.next()
+	if err != nil {
+		return nil, err
 	}
 
-	rule := it.rules[it.pos]
+	rule := it.rules[pos]
 	rule.HighlightResult = nil
 	return &rule, nil
 }
-
-func (it *RuleIterator) loadNextPage() error {
-	it.pos = -1
-	it.page++
-
-	res, err := it.index.SearchRules(Map{"query": ""})
-	if err != nil {
-		return err
-	}
-
-	it.rules = res.Hits
-	return nil
-}
-
-func (it *RuleIterator) reset() {
-	it.rules = nil
-	it.page = -1
-	it.pos = 0
-}
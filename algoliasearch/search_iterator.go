@@ -0,0 +1,119 @@
+package algoliasearch
+
+import "context"
+
+// SearchWarnings reports why the hits returned by a SearchIterator may not be
+// all the hits matching the query.
+type SearchWarnings struct {
+	// NonExhaustiveNbHits is set if at least one page was returned with
+	// `exhaustiveNbHits` set to false: the number of hits is approximated.
+	NonExhaustiveNbHits bool
+
+	// TimeoutHits is set if the engine timed out while retrieving the hits of
+	// at least one page, which may then be incomplete.
+	TimeoutHits bool
+
+	// TimeoutCounts is set if the engine timed out while computing the number
+	// of hits of at least one page.
+	TimeoutCounts bool
+
+	// Truncated is set once the last page has been reached while fewer hits
+	// than `nbHits` were returned, for instance because of the
+	// `paginationLimitedTo` setting of the index.
+	Truncated bool
+}
+
+// Any returns true if any of the warnings is set.
+func (w SearchWarnings) Any() bool {
+	return w.NonExhaustiveNbHits || w.TimeoutHits || w.TimeoutCounts || w.Truncated
+}
+
+// SearchIterator is the exposed structure to iterate over all the hits of a
+// search query, page after page. Contrary to `Index.BrowseAll`, the hits are
+// returned in ranking order and only the hits reachable through the
+// pagination of the index (see the `paginationLimitedTo` setting) can be
+// retrieved.
+type SearchIterator struct {
+	paginator *paginator
+	res       QueryRes
+	warnings  SearchWarnings
+}
+
+// NewSearchIterator returns a new SearchIterator that will iterate over all
+// the hits of the `index` matching the `query` and the given `params`.
+func NewSearchIterator(index Index, query string, params Map) *SearchIterator {
+	return NewSearchIteratorWithOptions(context.Background(), index, query, params, IteratorOptions{})
+}
+
+// NewSearchIteratorWithOptions is the same as NewSearchIterator but the
+// iteration stops as soon as `ctx` is done and the pages are retrieved
+// according to the given IteratorOptions. The `page` and `hitsPerPage`
+// parameters are controlled by the iterator and must not be set in `params`.
+func NewSearchIteratorWithOptions(ctx context.Context, index Index, query string, params Map, options IteratorOptions) *SearchIterator {
+	it := &SearchIterator{}
+	it.paginator = newPaginator(ctx, options.hitsPerPage(), NoMoreHitsErr, func(page, hitsPerPage int) (int, bool, error) {
+		pageParams := duplicateMap(params)
+		pageParams["page"] = page
+		pageParams["hitsPerPage"] = hitsPerPage
+
+		res, err := index.SearchWithRequestOptions(query, pageParams, options.RequestOptions)
+		if err != nil {
+			return 0, false, err
+		}
+
+		it.res = res
+		it.addWarnings(res, page, hitsPerPage)
+		return len(res.Hits), page+1 >= res.NbPages, nil
+	})
+	return it
+}
+
+// addWarnings records the warnings reported by the page `page` of results.
+func (it *SearchIterator) addWarnings(res QueryRes, page, hitsPerPage int) {
+	if !res.ExhaustiveNbHits {
+		it.warnings.NonExhaustiveNbHits = true
+	}
+
+	if res.TimeoutHits {
+		it.warnings.TimeoutHits = true
+	}
+
+	if res.TimeoutCounts {
+		it.warnings.TimeoutCounts = true
+	}
+
+	if page+1 >= res.NbPages && page*hitsPerPage+len(res.Hits) < res.NbHits {
+		it.warnings.Truncated = true
+	}
+
+	if it.warnings.Any() {
+		debug("* SEARCH ITERATOR index=%s page=%d warnings=%+v", res.Index, page, it.warnings)
+	}
+}
+
+// Next returns the next hit. Every call to Next should yield a different hit
+// with a nil error until the algoliasearch.NoMoreHitsErr is returned which
+// means that all the hits have been retrieved. If the error is of a different
+// type, it means that the iteration could not have been done correctly.
+func (it *SearchIterator) Next() (Map, error) {
+	pos, err := it.paginator.next()
+	if err != nil {
+		return nil, err
+	}
+
+	return it.res.Hits[pos], nil
+}
+
+// Warnings returns the warnings reported by all the pages retrieved so far.
+// They should be checked once the iteration is over to know whether all the
+// matching hits have been returned.
+func (it *SearchIterator) Warnings() SearchWarnings {
+	return it.warnings
+}
+
+// LastRes returns the last page of results retrieved by the iterator, which
+// gives access to the other information returned along with the hits, such
+// as `nbHits` or the facets.
+func (it *SearchIterator) LastRes() QueryRes {
+	return it.res
+}
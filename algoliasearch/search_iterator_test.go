@@ -0,0 +1,70 @@
+package algoliasearch
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// searchStubIndex is an Index whose Search method serves `nbReachable` hits
+// out of `nbHits`, as if the pagination was limited. Calling any other method
+// panics.
+type searchStubIndex struct {
+	Index
+	nbHits      int
+	nbReachable int
+	timeoutPage int
+	params      []Map
+}
+
+func (i *searchStubIndex) SearchWithRequestOptions(query string, params Map, opts *RequestOptions) (res QueryRes, err error) {
+	i.params = append(i.params, params)
+
+	page, hitsPerPage := params["page"].(int), params["hitsPerPage"].(int)
+	for n := page * hitsPerPage; n < i.nbReachable && n < (page+1)*hitsPerPage; n++ {
+		res.Hits = append(res.Hits, Map{"objectID": strconv.Itoa(n)})
+	}
+	res.NbHits = i.nbHits
+	res.NbPages = (i.nbReachable + hitsPerPage - 1) / hitsPerPage
+	res.ExhaustiveNbHits = true
+	res.TimeoutHits = page == i.timeoutPage
+	return
+}
+
+func searchAll(t *testing.T, it *SearchIterator) (objectIDs []string) {
+	for {
+		hit, err := it.Next()
+		if err != nil {
+			require.Equal(t, NoMoreHitsErr, err)
+			return
+		}
+		objectIDs = append(objectIDs, hit["objectID"].(string))
+	}
+}
+
+func TestSearchIterator(t *testing.T) {
+	i := &searchStubIndex{nbHits: 5, nbReachable: 5, timeoutPage: -1}
+	params := Map{"getRankingInfo": true}
+	it := NewSearchIteratorWithOptions(context.Background(), i, "query", params, IteratorOptions{HitsPerPage: 2})
+
+	require.Equal(t, []string{"0", "1", "2", "3", "4"}, searchAll(t, it))
+	require.False(t, it.Warnings().Any())
+	require.Equal(t, 5, it.LastRes().NbHits)
+	require.Equal(t, []Map{
+		{"getRankingInfo": true, "page": 0, "hitsPerPage": 2},
+		{"getRankingInfo": true, "page": 1, "hitsPerPage": 2},
+		{"getRankingInfo": true, "page": 2, "hitsPerPage": 2},
+	}, i.params)
+	require.Equal(t, Map{"getRankingInfo": true}, params, "should not modify the given parameters")
+}
+
+func TestSearchIterator_warnings(t *testing.T) {
+	i := &searchStubIndex{nbHits: 10, nbReachable: 3, timeoutPage: 1}
+	it := NewSearchIteratorWithOptions(context.Background(), i, "", nil, IteratorOptions{HitsPerPage: 2})
+
+	require.Equal(t, []string{"0", "1", "2"}, searchAll(t, it))
+	require.Equal(t, SearchWarnings{TimeoutHits: true, Truncated: true}, it.Warnings())
+	require.True(t, it.Warnings().Any())
+}
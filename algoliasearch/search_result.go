@@ -0,0 +1,118 @@
+package algoliasearch
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FacetCounts returns the number of hits per value of each facet, as found in
+// the `facets` of the response.
+func (r QueryRes) FacetCounts() (counts map[string]map[string]int, err error) {
+	err = decodeInto(r.Facets, &counts, "facets")
+	return
+}
+
+// TypedFacetsStats returns the statistics of each numeric facet, as found in
+// the `facets_stats` of the response.
+func (r QueryRes) TypedFacetsStats() (stats map[string]FacetStats, err error) {
+	err = decodeInto(r.FacetsStats, &stats, "facets_stats")
+	return
+}
+
+// HitHighlightResult returns the highlight result of the given `attribute` of
+// the `hit`. Nested attributes are reached with a dot-separated path, where
+// array elements are designated by their position, such as `authors.0.name`.
+func HitHighlightResult(hit Map, attribute string) (res HighlightResult, err error) {
+	err = decodeHitAttribute(hit, "_highlightResult", attribute, &res)
+	return
+}
+
+// HitSnippetResult returns the snippet result of the given `attribute` of the
+// `hit`. Nested attributes are reached the same way as with
+// HitHighlightResult.
+func HitSnippetResult(hit Map, attribute string) (res SnippetResult, err error) {
+	err = decodeHitAttribute(hit, "_snippetResult", attribute, &res)
+	return
+}
+
+// HitRankingInfo returns the ranking information of the `hit`, which is only
+// present if the `getRankingInfo` parameter was enabled.
+func HitRankingInfo(hit Map) (res RankingInfo, err error) {
+	info, ok := hit["_rankingInfo"]
+	if !ok {
+		err = fmt.Errorf("hit has no `_rankingInfo`, `getRankingInfo` should be enabled")
+		return
+	}
+	err = decodeInto(info, &res, "_rankingInfo")
+	return
+}
+
+// Render returns the highlighted value where the default highlighting tags
+// are replaced with `preTag` and `postTag`.
+func (h HighlightResult) Render(preTag, postTag string) string {
+	return ReplaceHighlightTags(h.Value, DefaultHighlightPreTag, DefaultHighlightPostTag, preTag, postTag)
+}
+
+// Render returns the snippet where the default highlighting tags are replaced
+// with `preTag` and `postTag`.
+func (s SnippetResult) Render(preTag, postTag string) string {
+	return ReplaceHighlightTags(s.Value, DefaultHighlightPreTag, DefaultHighlightPostTag, preTag, postTag)
+}
+
+// ReplaceHighlightTags returns `value` where the highlighting tags `fromPre`
+// and `fromPost` are replaced with `toPre` and `toPost`. It should be used
+// instead of the Render methods when the search was performed with custom
+// `highlightPreTag` and `highlightPostTag` parameters.
+func ReplaceHighlightTags(value, fromPre, fromPost, toPre, toPost string) string {
+	return strings.NewReplacer(fromPre, toPre, fromPost, toPost).Replace(value)
+}
+
+// decodeHitAttribute decodes into `dst` the value found in the `field` of the
+// hit (such as `_highlightResult`) at the given dot-separated `attribute`
+// path.
+func decodeHitAttribute(hit Map, field, attribute string, dst interface{}) error {
+	value, ok := hit[field]
+	if !ok {
+		return fmt.Errorf("hit has no `%s`", field)
+	}
+
+	for _, key := range strings.Split(attribute, ".") {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			value, ok = v[key]
+		case Map:
+			value, ok = v[key]
+		case []interface{}:
+			n, err := strconv.Atoi(key)
+			ok = err == nil && n >= 0 && n < len(v)
+			if ok {
+				value = v[n]
+			}
+		default:
+			ok = false
+		}
+
+		if !ok {
+			return fmt.Errorf("no `%s` found in `%s`", attribute, field)
+		}
+	}
+
+	return decodeInto(value, dst, field+"."+attribute)
+}
+
+// decodeInto converts the untyped `value`, as decoded from a JSON response,
+// into `dst`.
+func decodeInto(value, dst interface{}, name string) error {
+	data, err := json.Marshal(value)
+	if err == nil {
+		err = json.Unmarshal(data, dst)
+	}
+
+	if err != nil {
+		return fmt.Errorf("cannot decode `%s`: %s", name, err)
+	}
+
+	return nil
+}
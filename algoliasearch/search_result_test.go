@@ -0,0 +1,74 @@
+package algoliasearch
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const searchResultJSON = `{
+  "hits": [{
+    "objectID": "1",
+    "name": "iPhone",
+    "authors": [{"name": "Steve"}],
+    "_highlightResult": {
+      "name": {"value": "<em>iPh</em>one", "matchLevel": "full", "matchedWords": ["iph"], "fullyHighlighted": false},
+      "authors": [{"name": {"value": "Steve", "matchLevel": "none", "matchedWords": []}}]
+    },
+    "_snippetResult": {
+      "name": {"value": "<em>iPh</em>one", "matchLevel": "full"}
+    },
+    "_rankingInfo": {
+      "nbTypos": 1, "firstMatchedWord": 0, "proximityDistance": 2, "userScore": 7,
+      "geoDistance": 120, "geoPrecision": 1, "nbExactWords": 0, "words": 1, "filters": 0,
+      "matchedGeoLocation": {"lat": 48.85, "lng": 2.35, "distance": 120}
+    }
+  }],
+  "facets": {"brand": {"apple": 12, "samsung": 3}},
+  "facets_stats": {"price": {"min": 10, "max": 1500, "avg": 420.5, "sum": 6307.5}}
+}`
+
+func TestQueryRes_typedResults(t *testing.T) {
+	var res QueryRes
+	require.NoError(t, json.Unmarshal([]byte(searchResultJSON), &res))
+
+	counts, err := res.FacetCounts()
+	require.NoError(t, err)
+	require.Equal(t, map[string]map[string]int{"brand": {"apple": 12, "samsung": 3}}, counts)
+
+	stats, err := res.TypedFacetsStats()
+	require.NoError(t, err)
+	require.Equal(t, map[string]FacetStats{"price": {Min: 10, Max: 1500, Avg: 420.5, Sum: 6307.5}}, stats)
+
+	hit := res.Hits[0]
+
+	highlight, err := HitHighlightResult(hit, "name")
+	require.NoError(t, err)
+	require.Equal(t, HighlightResult{Value: "<em>iPh</em>one", MatchLevel: "full", MatchedWords: []string{"iph"}}, highlight)
+	require.Equal(t, "<mark>iPh</mark>one", highlight.Render("<mark>", "</mark>"))
+
+	nested, err := HitHighlightResult(hit, "authors.0.name")
+	require.NoError(t, err)
+	require.Equal(t, "none", nested.MatchLevel)
+
+	_, err = HitHighlightResult(hit, "authors.1.name")
+	require.Error(t, err)
+
+	snippet, err := HitSnippetResult(hit, "name")
+	require.NoError(t, err)
+	require.Equal(t, "**iPh**one", snippet.Render("**", "**"))
+
+	info, err := HitRankingInfo(hit)
+	require.NoError(t, err)
+	require.Equal(t, 1, info.NbTypos)
+	require.Equal(t, 7, info.UserScore)
+	require.Equal(t, &MatchedGeoLocation{Lat: 48.85, Lng: 2.35, Distance: 120}, info.MatchedGeoLocation)
+
+	_, err = HitRankingInfo(Map{"objectID": "1"})
+	require.Error(t, err)
+}
+
+func TestReplaceHighlightTags(t *testing.T) {
+	require.Equal(t, "<b>a</b> and <b>b</b>", ReplaceHighlightTags("[a] and [b]", "[", "]", "<b>", "</b>"))
+}
@@ -0,0 +1,96 @@
+package algoliasearch
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+)
+
+// DiffSettings returns the changes needed to go from the `current` Settings
+// of an index to the `desired` ones. The desired Settings are declarative:
+// their nil fields are not managed and never produce any change, while all
+// the other fields are compared with the current ones once normalized by
+// `Settings.ToMap`. The setting accepting several types, such as `distinct`
+// or `ignorePlurals`, are therefore compared regardless of how they have
+// been decoded.
+func DiffSettings(current, desired Settings) SettingsDiff {
+	return diffSettingMaps(current.ToMap(), desired.ToMap())
+}
+
+// diffSettingMaps returns the changes needed to go from the `before` settings
+// to the `after` ones, both normalized as by `Settings.ToMap`. Settings which
+// are not present in `after` are not managed.
+func diffSettingMaps(before, after Map) (diff SettingsDiff) {
+	for setting, value := range after {
+		if !settingValuesAreEqual(before[setting], value) {
+			diff = append(diff, SettingsChange{
+				Setting: setting,
+				Before:  before[setting],
+				After:   value,
+			})
+		}
+	}
+
+	sort.Sort(settingsChangesBySetting(diff))
+	return
+}
+
+// settingValuesAreEqual compares two normalized setting values through their
+// JSON representation, as this is what the API eventually stores, so that
+// for instance `Map` and `map[string]interface{}` values or `int` and
+// `float64` numbers are equal if they hold the same data.
+func settingValuesAreEqual(v1, v2 interface{}) bool {
+	if v1 == nil || v2 == nil {
+		return v1 == nil && v2 == nil
+	}
+
+	data1, err1 := json.Marshal(v1)
+	data2, err2 := json.Marshal(v2)
+	if err1 != nil || err2 != nil {
+		return reflect.DeepEqual(v1, v2)
+	}
+
+	return bytes.Equal(data1, data2)
+}
+
+type settingsChangesBySetting SettingsDiff
+
+func (d settingsChangesBySetting) Len() int           { return len(d) }
+func (d settingsChangesBySetting) Less(i, j int) bool { return d[i].Setting < d[j].Setting }
+func (d settingsChangesBySetting) Swap(i, j int)      { d[i], d[j] = d[j], d[i] }
+
+func (i *index) ApplySettings(desired Settings, options ApplyOptions) (res ApplySettingsRes, err error) {
+	return i.ApplySettingsWithRequestOptions(desired, options, nil)
+}
+
+func (i *index) ApplySettingsWithRequestOptions(desired Settings, options ApplyOptions, opts *RequestOptions) (res ApplySettingsRes, err error) {
+	if err = i.client.checkSettings(desired.ToMap()); err != nil {
+		return
+	}
+
+	current, err := i.GetSettingsWithRequestOptions(opts)
+	if err != nil {
+		return
+	}
+
+	res.Changes = DiffSettings(current, desired)
+	if options.DryRun || len(res.Changes) == 0 {
+		return
+	}
+
+	settings := res.Changes.ToMap()
+	settings["forwardToReplicas"] = options.ForwardToReplicas
+
+	setRes, err := i.SetSettingsWithRequestOptions(settings, opts)
+	if err != nil {
+		return
+	}
+
+	if err = i.WaitTaskWithRequestOptions(setRes.TaskID, opts); err != nil {
+		return
+	}
+
+	res.TaskID = setRes.TaskID
+	return
+}
@@ -0,0 +1,133 @@
+package algoliasearch
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffSettings(t *testing.T) {
+	var current Settings
+	err := json.Unmarshal([]byte(`{
+		"hitsPerPage": 20,
+		"distinct": 2,
+		"ignorePlurals": ["en", "fr"],
+		"removeStopWords": false,
+		"customRanking": ["desc(popularity)"],
+		"userData": {"version": 1}
+	}`), &current)
+	require.NoError(t, err)
+
+	t.Log("TestDiffSettings: Identical values decoded differently are not changes")
+	{
+		desired := Settings{
+			HitsPerPage:   Int(20),
+			Distinct:      2,
+			IgnorePlurals: []string{"en", "fr"},
+			UserData:      Map{"version": 1},
+		}
+		require.Empty(t, DiffSettings(current, desired))
+	}
+
+	t.Log("TestDiffSettings: Only the desired fields are compared")
+	{
+		desired := Settings{
+			HitsPerPage:     Int(10),
+			Distinct:        true,
+			RemoveStopWords: []string{"en"},
+			CustomRanking:   []string{"desc(popularity)"},
+			Ranking:         []string{},
+		}
+		diff := DiffSettings(current, desired)
+		require.Equal(t, SettingsDiff{
+			{Setting: "distinct", Before: 2, After: true},
+			{Setting: "hitsPerPage", Before: 20, After: 10},
+			{Setting: "ranking", Before: nil, After: []string{}},
+			{Setting: "removeStopWords", Before: false, After: []string{"en"}},
+		}, diff)
+
+		require.Equal(t, ""+
+			"distinct: 2 -> true\n"+
+			"hitsPerPage: 20 -> 10\n"+
+			"ranking: <unset> -> []\n"+
+			"removeStopWords: false -> [\"en\"]", diff.String())
+
+		data, err := json.Marshal(diff[:2])
+		require.NoError(t, err)
+		require.JSONEq(t, `[
+			{"setting": "distinct", "before": 2, "after": true},
+			{"setting": "hitsPerPage", "before": 20, "after": 10}
+		]`, string(data))
+
+		require.Equal(t, Map{
+			"distinct":        true,
+			"hitsPerPage":     10,
+			"ranking":         []string{},
+			"removeStopWords": []string{"en"},
+		}, diff.ToMap())
+	}
+}
+
+func TestIndex_ApplySettings(t *testing.T) {
+	// The same response is used for the settings, the settings task and its
+	// status.
+	rt := &recordingRoundTripper{response: `{"hitsPerPage":20,"enableRules":true,"taskID":42,"status":"published"}`}
+	c := NewClient("appID", "apiKey")
+	c.SetHTTPClient(&http.Client{Transport: rt})
+	i := c.InitIndex("products")
+
+	desired := Settings{HitsPerPage: Int(10), EnableRules: Bool(true)}
+
+	t.Log("TestIndex_ApplySettings: Dry runs do not send anything")
+	{
+		res, err := i.ApplySettings(desired, ApplyOptions{DryRun: true})
+		require.NoError(t, err)
+		require.Equal(t, SettingsDiff{{Setting: "hitsPerPage", Before: 20, After: 10}}, res.Changes)
+		require.Equal(t, 0, res.TaskID)
+		require.Len(t, rt.requests, 1)
+		require.Equal(t, "GET", rt.requests[0].Method)
+	}
+
+	t.Log("TestIndex_ApplySettings: Only send the changed settings and wait for them")
+	{
+		rt.requests, rt.bodies = nil, nil
+		res, err := i.ApplySettings(desired, ApplyOptions{ForwardToReplicas: true})
+		require.NoError(t, err)
+		require.Equal(t, 42, res.TaskID)
+		require.Len(t, rt.requests, 3)
+
+		require.Equal(t, "PUT", rt.requests[1].Method)
+		require.Equal(t, "/1/indexes/products/settings", rt.requests[1].URL.Path)
+		require.Equal(t, "true", rt.requests[1].URL.Query().Get("forwardToReplicas"))
+		require.JSONEq(t, `{"hitsPerPage":10}`, rt.bodies[1])
+
+		require.Equal(t, "/1/indexes/products/task/42", rt.requests[2].URL.Path)
+	}
+
+	t.Log("TestIndex_ApplySettings: Do not send anything without changes")
+	{
+		rt.requests, rt.bodies = nil, nil
+		res, err := i.ApplySettings(Settings{HitsPerPage: Int(20)}, ApplyOptions{})
+		require.NoError(t, err)
+		require.Empty(t, res.Changes)
+		require.Len(t, rt.requests, 1)
+	}
+
+	t.Log("TestIndex_ApplySettings: Reject invalid settings")
+	{
+		rt.requests, rt.bodies = nil, nil
+		_, err := i.ApplySettings(Settings{TypoTolerance: 1}, ApplyOptions{})
+		require.Error(t, err)
+		require.Empty(t, rt.requests)
+	}
+
+	t.Log("TestIndex_ApplySettings: Follow the ValidationPolicy of the client, even for dry runs")
+	{
+		c.SetValidationPolicy(ValidationPolicy{Mode: StrictValidation})
+		_, err := i.ApplySettings(Settings{QueryType: String("prefixFirst")}, ApplyOptions{DryRun: true})
+		require.EqualError(t, err, "invalid `queryType` value `prefixFirst`: should be one of `prefixAll`, `prefixLast`, `prefixNone`")
+		require.Empty(t, rt.requests)
+	}
+}
@@ -0,0 +1,226 @@
+package algoliasearch
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// settingsSnapshotIDFormat is the time layout of the first part of the
+// snapshot IDs, so that they sort chronologically.
+const settingsSnapshotIDFormat = "20060102T150405.000000000Z"
+
+func (i *index) RollbackSettings(snapshotID string) (res UpdateTaskRes, err error) {
+	return i.RollbackSettingsWithRequestOptions(snapshotID, nil)
+}
+
+func (i *index) RollbackSettingsWithRequestOptions(snapshotID string, opts *RequestOptions) (res UpdateTaskRes, err error) {
+	store := i.client.settingsSnapshotStore
+	if store == nil {
+		err = NoSettingsSnapshotStoreErr
+		return
+	}
+
+	snapshot, err := store.GetSettingsSnapshot(i.name, snapshotID)
+	if err != nil {
+		return
+	}
+
+	current, err := i.GetSettingsWithRequestOptions(opts)
+	if err != nil {
+		return
+	}
+
+	return i.SetSettingsWithRequestOptions(settingsToRestore(current.ToMap(), snapshot.Settings.ToMap()), opts)
+}
+
+// settingsToRestore returns the settings to send to go from the `current`
+// settings back to the `snapshot` ones: the settings which changed since the
+// snapshot, including the ones which were not set at the time and are
+// therefore reset. The replicas, which may have been attached or detached
+// since, are left untouched.
+func settingsToRestore(current, snapshot Map) Map {
+	for _, k := range []string{"replicas", "slaves", "primary"} {
+		delete(current, k)
+		delete(snapshot, k)
+	}
+
+	for k := range current {
+		if _, ok := snapshot[k]; !ok {
+			snapshot[k] = nil
+		}
+	}
+
+	return diffSettingMaps(current, snapshot).ToMap()
+}
+
+// snapshotSettings saves the current settings of the index into the
+// SettingsSnapshotStore of the client, if any.
+func (i *index) snapshotSettings(opts *RequestOptions) error {
+	store := i.client.settingsSnapshotStore
+	if store == nil {
+		return nil
+	}
+
+	settings, err := i.GetSettingsWithRequestOptions(opts)
+	if err != nil {
+		return fmt.Errorf("cannot retrieve the settings to snapshot: %s", err)
+	}
+
+	snapshot := SettingsSnapshot{
+		IndexName: i.name,
+		CreatedAt: time.Now().UTC(),
+		Settings:  settings,
+	}
+	if opts != nil {
+		snapshot.Author = opts.SnapshotAuthor
+	}
+	if snapshot.ID, err = newSettingsSnapshotID(snapshot.CreatedAt); err != nil {
+		return err
+	}
+
+	if err = store.SaveSettingsSnapshot(snapshot); err != nil {
+		return fmt.Errorf("cannot save settings snapshot: %s", err)
+	}
+
+	return nil
+}
+
+// newSettingsSnapshotID returns a unique snapshot ID made of the given
+// creation time followed by a random suffix.
+func newSettingsSnapshotID(createdAt time.Time) (string, error) {
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", fmt.Errorf("cannot generate settings snapshot ID: %s", err)
+	}
+	return createdAt.Format(settingsSnapshotIDFormat) + "-" + hex.EncodeToString(suffix), nil
+}
+
+// FileSettingsSnapshotStore is a SettingsSnapshotStore which saves each
+// snapshot as a JSON file named after its ID, in a sub-directory of its root
+// directory named after the escaped name of the index.
+type FileSettingsSnapshotStore struct {
+	dir string
+}
+
+// NewFileSettingsSnapshotStore returns a FileSettingsSnapshotStore saving the
+// snapshots under the directory `dir`, which is created if needed.
+func NewFileSettingsSnapshotStore(dir string) *FileSettingsSnapshotStore {
+	return &FileSettingsSnapshotStore{dir: dir}
+}
+
+func (s *FileSettingsSnapshotStore) SaveSettingsSnapshot(snapshot SettingsSnapshot) error {
+	if !isValidSettingsSnapshotID(snapshot.ID) {
+		return fmt.Errorf("invalid settings snapshot ID %q", snapshot.ID)
+	}
+
+	dir := s.indexDir(snapshot.IndexName)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// The snapshot is written to a temporary file first so that a partially
+	// written snapshot is never read.
+	tmp, err := ioutil.TempFile(dir, ".tmp-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), filepath.Join(dir, snapshot.ID+".json"))
+}
+
+func (s *FileSettingsSnapshotStore) GetSettingsSnapshot(indexName, id string) (snapshot SettingsSnapshot, err error) {
+	if !isValidSettingsSnapshotID(id) {
+		err = SettingsSnapshotNotFoundErr
+		return
+	}
+
+	snapshot, err = readSettingsSnapshot(filepath.Join(s.indexDir(indexName), id+".json"))
+	if os.IsNotExist(err) {
+		err = SettingsSnapshotNotFoundErr
+	}
+	return
+}
+
+func (s *FileSettingsSnapshotStore) ListSettingsSnapshots(indexName string) (snapshots []SettingsSnapshot, err error) {
+	dir := s.indexDir(indexName)
+
+	files, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		err = nil
+		return
+	} else if err != nil {
+		return
+	}
+
+	for _, file := range files {
+		name := file.Name()
+		if file.IsDir() || strings.HasPrefix(name, ".") || filepath.Ext(name) != ".json" {
+			continue
+		}
+
+		var snapshot SettingsSnapshot
+		if snapshot, err = readSettingsSnapshot(filepath.Join(dir, name)); err != nil {
+			return
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	sort.Sort(settingsSnapshotsByCreation(snapshots))
+	return
+}
+
+func (s *FileSettingsSnapshotStore) indexDir(indexName string) string {
+	return filepath.Join(s.dir, url.QueryEscape(indexName))
+}
+
+func readSettingsSnapshot(path string) (snapshot SettingsSnapshot, err error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(data, &snapshot); err != nil {
+		err = fmt.Errorf("cannot read settings snapshot %s: %s", path, err)
+	}
+	return
+}
+
+// isValidSettingsSnapshotID makes sure that the given ID can safely be used
+// as a file name.
+func isValidSettingsSnapshotID(id string) bool {
+	return id != "" && !strings.HasPrefix(id, ".") && !strings.ContainsAny(id, `/\`)
+}
+
+type settingsSnapshotsByCreation []SettingsSnapshot
+
+func (s settingsSnapshotsByCreation) Len() int      { return len(s) }
+func (s settingsSnapshotsByCreation) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s settingsSnapshotsByCreation) Less(i, j int) bool {
+	if !s[i].CreatedAt.Equal(s[j].CreatedAt) {
+		return s[i].CreatedAt.Before(s[j].CreatedAt)
+	}
+	return s[i].ID < s[j].ID
+}
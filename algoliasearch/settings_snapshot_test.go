@@ -0,0 +1,120 @@
+package algoliasearch
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSettingsSnapshotStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "TestFileSettingsSnapshotStore")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	store := NewFileSettingsSnapshotStore(dir)
+
+	snapshots, err := store.ListSettingsSnapshots("products/en")
+	require.NoError(t, err)
+	require.Empty(t, snapshots)
+
+	now := time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+	older := SettingsSnapshot{ID: "b", IndexName: "products/en", CreatedAt: now, Author: "alice", Settings: Settings{HitsPerPage: Int(10)}}
+	newer := SettingsSnapshot{ID: "a", IndexName: "products/en", CreatedAt: now.Add(time.Minute), Settings: Settings{HitsPerPage: Int(20)}}
+	other := SettingsSnapshot{ID: "c", IndexName: "products", CreatedAt: now, Settings: Settings{HitsPerPage: Int(30)}}
+
+	for _, snapshot := range []SettingsSnapshot{newer, older, other} {
+		require.NoError(t, store.SaveSettingsSnapshot(snapshot))
+	}
+	require.Error(t, store.SaveSettingsSnapshot(SettingsSnapshot{ID: "../d", IndexName: "products"}))
+
+	snapshots, err = store.ListSettingsSnapshots("products/en")
+	require.NoError(t, err)
+	require.Equal(t, []SettingsSnapshot{older, newer}, snapshots)
+
+	snapshot, err := store.GetSettingsSnapshot("products", "c")
+	require.NoError(t, err)
+	require.Equal(t, other, snapshot)
+
+	for _, id := range []string{"a", "", "../products/c"} {
+		_, err = store.GetSettingsSnapshot("products", id)
+		require.Equal(t, SettingsSnapshotNotFoundErr, err, "snapshot %q", id)
+	}
+}
+
+func TestIndex_RollbackSettings(t *testing.T) {
+	dir, err := ioutil.TempDir("", "TestIndex_RollbackSettings")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	rt := &routingRoundTripper{responses: map[string]string{
+		"GET /1/indexes/products/settings": `{"hitsPerPage": 10, "customRanking": ["desc(popularity)"], "distinct": 1, "replicas": ["products_old"]}`,
+		"PUT /1/indexes/products/settings": `{"taskID": 1}`,
+	}}
+	c := NewClient("appID", "apiKey")
+	c.SetHTTPClient(&http.Client{Transport: rt})
+	i := c.InitIndex("products")
+
+	t.Log("TestIndex_RollbackSettings: No snapshot is taken by default")
+	{
+		_, err = i.SetSettings(Map{"hitsPerPage": 20})
+		require.NoError(t, err)
+		require.Equal(t, []string{"PUT /1/indexes/products/settings"}, rt.requests)
+
+		_, err = i.RollbackSettings("unknown")
+		require.Equal(t, NoSettingsSnapshotStoreErr, err)
+	}
+
+	store := NewFileSettingsSnapshotStore(dir)
+	c.SetSettingsSnapshotStore(store)
+
+	t.Log("TestIndex_RollbackSettings: Snapshot the settings before changing them")
+	{
+		rt.requests, rt.bodies = nil, nil
+		_, err = i.SetSettingsWithRequestOptions(Map{"hitsPerPage": 20}, &RequestOptions{SnapshotAuthor: "deploy-bot"})
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"GET /1/indexes/products/settings",
+			"PUT /1/indexes/products/settings",
+		}, rt.requests)
+
+		snapshots, err := store.ListSettingsSnapshots("products")
+		require.NoError(t, err)
+		require.Len(t, snapshots, 1)
+		require.Equal(t, "products", snapshots[0].IndexName)
+		require.Equal(t, "deploy-bot", snapshots[0].Author)
+		require.Equal(t, Int(10), snapshots[0].Settings.HitsPerPage)
+		require.WithinDuration(t, time.Now(), snapshots[0].CreatedAt, time.Minute)
+
+		t.Log("TestIndex_RollbackSettings: Restore a snapshot")
+		rt.responses["GET /1/indexes/products/settings"] = `{"hitsPerPage": 20, "customRanking": ["desc(popularity)"], "distinct": 1, "attributeForDistinct": "sku", "replicas": ["products_new"]}`
+		rt.requests, rt.bodies = nil, nil
+		_, err = i.RollbackSettings(snapshots[0].ID)
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"GET /1/indexes/products/settings",
+			"GET /1/indexes/products/settings",
+			"PUT /1/indexes/products/settings",
+		}, rt.requests)
+		require.JSONEq(t, `{"hitsPerPage": 10, "attributeForDistinct": null}`, rt.bodies[2])
+
+		snapshots, err = store.ListSettingsSnapshots("products")
+		require.NoError(t, err)
+		require.Len(t, snapshots, 2)
+
+		_, err = i.RollbackSettings("unknown")
+		require.Equal(t, SettingsSnapshotNotFoundErr, err)
+	}
+
+	t.Log("TestIndex_RollbackSettings: Do not change the settings if they cannot be snapshotted")
+	{
+		delete(rt.responses, "GET /1/indexes/products/settings")
+		rt.requests, rt.bodies = nil, nil
+		_, err = i.SetSettings(Map{"hitsPerPage": 20})
+		require.Error(t, err)
+		require.Equal(t, []string{"GET /1/indexes/products/settings"}, rt.requests)
+	}
+}
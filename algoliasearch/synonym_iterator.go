@@ -1,25 +1,37 @@
 package algoliasearch
 
+import "context"
+
 // SynonymIterator is the exposed structure to iterate over all the synonyms of
 // an index.
 type SynonymIterator struct {
-	index       Index
-	synonyms    []Synonym
-	hitsPerPage int
-	page        int
-	pos         int
+	paginator *paginator
+	synonyms  []Synonym
 }
 
 // NewSynonymIterator returns a new SynonymIterator that will iterate over all
 // the synonyms of the declared index.
 func NewSynonymIterator(index Index) *SynonymIterator {
-	return &SynonymIterator{
-		index:       index,
-		synonyms:    nil,
-		hitsPerPage: 1000,
-		page:        -1,
-		pos:         -1,
-	}
+	return NewSynonymIteratorWithOptions(context.Background(), index, IteratorOptions{})
+}
+
+// NewSynonymIteratorWithOptions is the same as NewSynonymIterator but the
+// iteration stops as soon as `ctx` is done and the pages are retrieved
+// according to the given IteratorOptions.
+func NewSynonymIteratorWithOptions(ctx context.Context, index Index, options IteratorOptions) *SynonymIterator {
+	it := &SynonymIterator{}
+	it.paginator = newPaginator(ctx, options.hitsPerPage(), NoMoreSynonymsErr, func(page, hitsPerPage int) (int, bool, error) {
+		synonyms, err := index.SearchSynonymsWithRequestOptions("", nil, page, hitsPerPage, options.RequestOptions)
+		if err != nil {
+			return 0, false, err
+		}
+
+		// As the number of synonyms is not returned, the last page is the
+		// first one which is not full.
+		it.synonyms = synonyms
+		return len(synonyms), len(synonyms) < hitsPerPage, nil
+	})
+	return it
 }
 
 // Next returns iterate to the next synonym of the underlying index. Every call
@@ -28,38 +40,12 @@ func NewSynonymIterator(index Index) *SynonymIterator {
 // synonyms have been retrieved. If the error is of a different type, it means
 // that the iteration could not have been done correctly.
 func (it *SynonymIterator) Next() (*Synonym, error) {
-	if it.synonyms == nil || it.pos >= len(it.synonyms) {
-		if err := it.loadNextPage(); err != nil {
-			it.reset()
-			return nil, err
-		}
-	}
-
-	it.pos++
-	if it.pos >= len(it.synonyms) {
-		return nil, NoMoreSynonymsErr
+	pos, err := it.paginator.next()
+	if err != nil {
+		return nil, err
 	}
 
-	synonym := it.synonyms[it.pos]
+	synonym := it.synonyms[pos]
 	synonym.HighlightResult = nil
 	return &synonym, nil
 }
-
-func (it *SynonymIterator) loadNextPage() error {
-	it.pos = -1
-	it.page++
-
-	synonyms, err := it.index.SearchSynonyms("", nil, it.page, it.hitsPerPage)
-	if err != nil {
-		return err
-	}
-
-	it.synonyms = synonyms
-	return nil
-}
-
-func (it *SynonymIterator) reset() {
-	it.synonyms = nil
-	it.page = -1
-	it.pos = 0
-}
@@ -29,6 +29,7 @@ const (
 	write
 	read
 	analyticsCall
+	insightsCall
 )
 
 // Transport is responsible for the connection and the retry strategy to
@@ -88,20 +89,34 @@ func (t *Transport) request(method, path string, body interface{}, typeCall int,
 		k = call.Write
 	case analyticsCall:
 		k = call.Analytics
+	case insightsCall:
+		k = call.Insights
 	default:
 		return nil, fmt.Errorf("unsupported call type %d", typeCall)
 	}
 
+	ctx := opts.context()
+
 	for _, h := range t.retryStrategy.GetTryableHosts(k) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		req, err := t.buildRequest(method, h.Host(), path, body, opts)
 		if err != nil {
 			return nil, err
 		}
 
 		debug("* REQUEST [%s] url=%s", method, req.URL)
-		bodyRes, code, err := t.do(req, h.Timeout())
+		bodyRes, code, err := t.do(ctx, req, h.Timeout())
 		debug("* RESPONSE [%d] err=%v body=%s", code, err, bodyRes)
 
+		// A request interrupted by the caller's context is neither retried nor
+		// reported to the retry strategy, as the host is not at fault.
+		if ctxErr := ctx.Err(); err != nil && ctxErr != nil {
+			return nil, ctxErr
+		}
+
 		switch t.retryStrategy.Decide(h, code, err) {
 		case Success:
 			return bodyRes, err
@@ -156,8 +171,8 @@ func (t *Transport) buildRequest(method, host, path string, body interface{}, op
 	return req, nil
 }
 
-func (t *Transport) do(req *http.Request, timeout time.Duration) ([]byte, int, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+func (t *Transport) do(ctx context.Context, req *http.Request, timeout time.Duration) ([]byte, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 	req = req.WithContext(ctx)
 
@@ -1,10 +1,13 @@
 package algoliasearch
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -75,3 +78,50 @@ func checkHeader(t *testing.T, header, value string, headers http.Header) {
 	require.Equal(t, 1, len(headers[header]), "header value slice should only contain one element")
 	require.Equal(t, value, headers[header][0], "header should have the correct value")
 }
+
+// blockingRoundTripper blocks every request until its context is done.
+type blockingRoundTripper struct {
+	mu       sync.Mutex
+	nbCalls  int
+	received chan struct{}
+}
+
+func (rt *blockingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.mu.Lock()
+	rt.nbCalls++
+	rt.mu.Unlock()
+
+	rt.received <- struct{}{}
+	<-req.Context().Done()
+	return nil, req.Context().Err()
+}
+
+func TestTransport_requestContext(t *testing.T) {
+	rt := &blockingRoundTripper{received: make(chan struct{}, 10)}
+	transport := NewTransport("appID", "apiKey")
+	transport.httpClient = &http.Client{Transport: rt}
+
+	t.Log("TestTransport_requestContext: Cancel an in-flight request")
+	{
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			<-rt.received
+			cancel()
+		}()
+
+		_, err := transport.request("GET", "/1/indexes", nil, read, &RequestOptions{Context: ctx})
+		require.Equal(t, context.Canceled, err)
+		require.Equal(t, 1, rt.nbCalls, "a cancelled request should not be retried on the other hosts")
+	}
+
+	t.Log("TestTransport_requestContext: Do not send requests whose context is already done")
+	{
+		ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+		defer cancel()
+		<-ctx.Done()
+
+		_, err := transport.request("GET", "/1/indexes", nil, read, &RequestOptions{Context: ctx})
+		require.Equal(t, context.DeadlineExceeded, err)
+		require.Equal(t, 1, rt.nbCalls)
+	}
+}
@@ -1,8 +1,8 @@
 package algoliasearch
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
 	"time"
 )
 
@@ -15,29 +15,105 @@ type ABTest struct {
 const ISO8601 = "2006-01-02T15:04:05Z"
 
 func (abTest ABTest) MarshalJSON() ([]byte, error) {
-	jsonVariants, err := json.Marshal(abTest.Variants)
-	if err != nil {
-		return nil, fmt.Errorf("cannot marshal variants: %s", err)
-	}
-	jsonAbTest := fmt.Sprintf(
-		`{"name":"%s","variants":%s,"endAt":"%s"}`,
-		abTest.Name,
-		jsonVariants,
-		abTest.EndAt.In(time.UTC).Format(ISO8601),
-	)
-	return []byte(jsonAbTest), nil
+	return json.Marshal(struct {
+		Name     string    `json:"name"`
+		Variants []Variant `json:"variants"`
+		EndAt    string    `json:"endAt"`
+	}{
+		Name:     abTest.Name,
+		Variants: abTest.Variants,
+		EndAt:    abTest.EndAt.In(time.UTC).Format(ISO8601),
+	})
 }
 
 type Variant struct {
 	Index             string `json:"index"`
 	TrafficPercentage int    `json:"trafficPercentage"`
 	Description       string `json:"description,omitempty"`
+
+	// CustomSearchParameters, if non-nil, are the search parameters applied
+	// to all the searches of the variant, on top of the ones sent by the
+	// users. They accept the same parameters as `Index.Search`. They can only
+	// be set on the second variant of an AB Test, whose Index must then be the
+	// same as the first variant's, in order to test a relevance tweak without
+	// any replica index.
+	CustomSearchParameters Map `json:"customSearchParameters,omitempty"`
+}
+
+// MarshalJSON encodes the variant like its struct tags specify, except that
+// the search parameters which are typed, such as GeoPoint, are encoded the
+// same way as for `Index.Search`.
+func (v Variant) MarshalJSON() ([]byte, error) {
+	type variant Variant
+	jsonVariant := variant(v)
+	jsonVariant.CustomSearchParameters = jsonQueryParams(v.CustomSearchParameters)
+	return json.Marshal(jsonVariant)
+}
+
+// jsonQueryParams returns a copy of the search parameters in which the typed
+// values which have no JSON representation of their own are replaced with
+// their string representation.
+func jsonQueryParams(params Map) Map {
+	if params == nil {
+		return nil
+	}
+
+	res := make(Map, len(params))
+	for k, v := range params {
+		if _, isMarshaler := v.(json.Marshaler); !isMarshaler {
+			if s, ok := v.(paramStringer); ok {
+				v = s.paramString()
+			}
+		}
+		res[k] = v
+	}
+	return res
 }
 
 type ABTestTaskRes struct {
 	ABTestID int    `json:"abTestID"`
 	Index    string `json:"index"`
 	TaskID   int    `json:"taskID"`
+	taskWaiter
+}
+
+// Wait blocks until the AB Test task is published on the index it refers to,
+// according to the WaitPolicy of the Client. It stops early if `ctx` is done.
+func (r ABTestTaskRes) Wait(ctx context.Context) error {
+	return r.wait(ctx, r.TaskID)
+}
+
+// GetABTestsParams are the parameters of `Analytics.GetABTests`. All of them
+// are optional.
+type GetABTestsParams struct {
+	// Offset and Limit paginate the list of AB Tests. By default, the API
+	// returns the first 10 AB Tests.
+	Offset int
+	Limit  int
+
+	// IndexPrefix and IndexSuffix, if non-empty, only keep the AB Tests whose
+	// variants target an index with the given prefix or suffix.
+	IndexPrefix string
+	IndexSuffix string
+}
+
+func (p GetABTestsParams) toMap() Map {
+	m := Map{}
+
+	if p.Offset > 0 {
+		m["offset"] = p.Offset
+	}
+	if p.Limit > 0 {
+		m["limit"] = p.Limit
+	}
+	if p.IndexPrefix != "" {
+		m["indexPrefix"] = p.IndexPrefix
+	}
+	if p.IndexSuffix != "" {
+		m["indexSuffix"] = p.IndexSuffix
+	}
+
+	return m
 }
 
 type GetABTestsRes struct {
@@ -58,15 +134,47 @@ type ABTestResponse struct {
 }
 
 type VariantResponse struct {
-	AverageClickPosition int     `json:"averageClickPosition"`
-	ClickCount           int     `json:"clickCount"`
-	ClickThroughRate     float64 `json:"clickThroughRate"`
-	ConversionCount      int     `json:"conversionCount"`
-	ConversionRate       float64 `json:"conversionRate"`
-	Description          string  `json:"description"`
-	Index                string  `json:"index"`
-	NoResultCount        int     `json:"noResultCount"`
-	SearchCount          int     `json:"searchCount"`
-	TrafficPercentage    int     `json:"trafficPercentage"`
-	UserCount            int     `json:"userCount"`
+	AverageClickPosition   int     `json:"averageClickPosition"`
+	ClickCount             int     `json:"clickCount"`
+	ClickThroughRate       float64 `json:"clickThroughRate"`
+	ConversionCount        int     `json:"conversionCount"`
+	ConversionRate         float64 `json:"conversionRate"`
+	CustomSearchParameters Map     `json:"customSearchParameters"`
+	Description            string  `json:"description"`
+	Index                  string  `json:"index"`
+	NoResultCount          int     `json:"noResultCount"`
+	SearchCount            int     `json:"searchCount"`
+	TrackedSearchCount     int     `json:"trackedSearchCount"`
+	TrafficPercentage      int     `json:"trafficPercentage"`
+	UserCount              int     `json:"userCount"`
+}
+
+// ABTestComparison is the result of the comparison of the two variants of an
+// AB Test for a given metric, as computed locally by
+// `ABTestResponse.CompareClicks` or `ABTestResponse.CompareConversions`.
+type ABTestComparison struct {
+	// RateA and RateB are the rates of the metric for the first and second
+	// variants respectively.
+	RateA float64
+	RateB float64
+
+	// Difference is RateB - RateA while Lift is the same difference relative
+	// to RateA. Lift is NaN if RateA is zero.
+	Difference float64
+	Lift       float64
+
+	// DifferenceLow and DifferenceHigh bound the confidence interval of
+	// Difference, at the confidence level given to the comparison.
+	DifferenceLow  float64
+	DifferenceHigh float64
+
+	// PValue is the p-value of the two-sided test of equality of the two
+	// rates. The smaller it is, the more significant the Difference.
+	PValue float64
+}
+
+// Significant returns true if the difference between the two variants is
+// significant at the given confidence level (such as 0.95).
+func (c ABTestComparison) Significant(confidenceLevel float64) bool {
+	return c.PValue < 1-confidenceLevel
 }
@@ -0,0 +1,171 @@
+package algoliasearch
+
+import "time"
+
+// AnalyticsDateFormat is the format of the dates used by the Algolia
+// Analytics API.
+const AnalyticsDateFormat = "2006-01-02"
+
+// AnalyticsParams are the parameters shared by the search analytics methods
+// of Analytics. Only Index is mandatory.
+type AnalyticsParams struct {
+	// Index is the name of the index to retrieve the analytics of.
+	Index string
+
+	// StartDate and EndDate restrict the analytics to the given range of
+	// days. By default, the last 8 days are used.
+	StartDate time.Time
+	EndDate   time.Time
+
+	// Tags restricts the analytics to the searches having the given analytics
+	// tags, using a filter expression such as `platform:mobile`.
+	Tags string
+
+	// Limit and Offset paginate the lists of results, such as the top
+	// searches. By default, the API returns the first 10 results.
+	Limit  int
+	Offset int
+
+	// ClickAnalytics adds the click and conversion metrics to the top
+	// searches and top hits.
+	ClickAnalytics bool
+}
+
+func (p AnalyticsParams) toMap() Map {
+	m := Map{"index": p.Index}
+
+	if !p.StartDate.IsZero() {
+		m["startDate"] = p.StartDate.Format(AnalyticsDateFormat)
+	}
+	if !p.EndDate.IsZero() {
+		m["endDate"] = p.EndDate.Format(AnalyticsDateFormat)
+	}
+	if p.Tags != "" {
+		m["tags"] = p.Tags
+	}
+	if p.Limit > 0 {
+		m["limit"] = p.Limit
+	}
+	if p.Offset > 0 {
+		m["offset"] = p.Offset
+	}
+	if p.ClickAnalytics {
+		m["clickAnalytics"] = true
+	}
+
+	return m
+}
+
+// ClickMetrics are the click and conversion metrics returned along with the
+// top searches and top hits when AnalyticsParams.ClickAnalytics is set.
+type ClickMetrics struct {
+	AverageClickPosition float64 `json:"averageClickPosition"`
+	ClickCount           int     `json:"clickCount"`
+	ClickThroughRate     float64 `json:"clickThroughRate"`
+	ConversionCount      int     `json:"conversionCount"`
+	ConversionRate       float64 `json:"conversionRate"`
+	TrackedSearchCount   int     `json:"trackedSearchCount"`
+}
+
+type TopSearch struct {
+	Search string `json:"search"`
+	Count  int    `json:"count"`
+	NbHits int    `json:"nbHits"`
+	ClickMetrics
+}
+
+type TopSearchesRes struct {
+	Searches []TopSearch `json:"searches"`
+}
+
+type NoResultsSearch struct {
+	Search          string `json:"search"`
+	Count           int    `json:"count"`
+	WithFilterCount int    `json:"withFilterCount"`
+}
+
+type NoResultsSearchesRes struct {
+	Searches []NoResultsSearch `json:"searches"`
+}
+
+type DailyCount struct {
+	Date  string `json:"date"`
+	Count int    `json:"count"`
+}
+
+type CountRes struct {
+	Count int          `json:"count"`
+	Dates []DailyCount `json:"dates"`
+}
+
+type DailyNoResultsRate struct {
+	Date          string  `json:"date"`
+	Rate          float64 `json:"rate"`
+	Count         int     `json:"count"`
+	NoResultCount int     `json:"noResultCount"`
+}
+
+type NoResultsRateRes struct {
+	Rate          float64              `json:"rate"`
+	Count         int                  `json:"count"`
+	NoResultCount int                  `json:"noResultCount"`
+	Dates         []DailyNoResultsRate `json:"dates"`
+}
+
+type TopHit struct {
+	Hit   string `json:"hit"`
+	Count int    `json:"count"`
+	ClickMetrics
+}
+
+type TopHitsRes struct {
+	Hits []TopHit `json:"hits"`
+}
+
+type TopFilterAttribute struct {
+	Attribute string `json:"attribute"`
+	Count     int    `json:"count"`
+}
+
+type TopFilterAttributesRes struct {
+	Attributes []TopFilterAttribute `json:"attributes"`
+}
+
+type TopFilterValue struct {
+	Attribute string `json:"attribute"`
+	Operator  string `json:"operator"`
+	Value     string `json:"value"`
+	Count     int    `json:"count"`
+}
+
+type TopFilterValuesRes struct {
+	Values []TopFilterValue `json:"values"`
+}
+
+type DailyClickThroughRate struct {
+	Date               string  `json:"date"`
+	Rate               float64 `json:"rate"`
+	ClickCount         int     `json:"clickCount"`
+	TrackedSearchCount int     `json:"trackedSearchCount"`
+}
+
+type ClickThroughRateRes struct {
+	Rate               float64                 `json:"rate"`
+	ClickCount         int                     `json:"clickCount"`
+	TrackedSearchCount int                     `json:"trackedSearchCount"`
+	Dates              []DailyClickThroughRate `json:"dates"`
+}
+
+type DailyConversionRate struct {
+	Date               string  `json:"date"`
+	Rate               float64 `json:"rate"`
+	ConversionCount    int     `json:"conversionCount"`
+	TrackedSearchCount int     `json:"trackedSearchCount"`
+}
+
+type ConversionRateRes struct {
+	Rate               float64               `json:"rate"`
+	ConversionCount    int                   `json:"conversionCount"`
+	TrackedSearchCount int                   `json:"trackedSearchCount"`
+	Dates              []DailyConversionRate `json:"dates"`
+}
@@ -0,0 +1,42 @@
+package algoliasearch
+
+import "time"
+
+const (
+	// IndexArchiveFormat identifies the archives produced by `Index.Export`.
+	IndexArchiveFormat = "algolia-index-archive"
+
+	// IndexArchiveVersion is the version of the archive format produced by
+	// `Index.Export`.
+	IndexArchiveVersion = 1
+)
+
+// IndexArchiveMetadata is the first JSON document of an index archive, as
+// produced by `Index.Export` and consumed by `Index.Import`.
+//
+// An index archive is a stream of newline-delimited JSON documents (NDJSON).
+// The first line is the IndexArchiveMetadata, holding the settings, Rules and
+// synonyms of the index. Every following line is one record of the index, as
+// returned by `Index.BrowseAll` but without the attributes computed at query
+// time such as `_highlightResult`:
+//
+//	{"format":"algolia-index-archive","version":1,"indexName":"...","exportedAt":"...","settings":{...},"rules":[...],"synonyms":[...]}
+//	{"objectID":"1", ...}
+//	{"objectID":"2", ...}
+type IndexArchiveMetadata struct {
+	Format     string    `json:"format"`
+	Version    int       `json:"version"`
+	IndexName  string    `json:"indexName"`
+	ExportedAt time.Time `json:"exportedAt"`
+	Settings   Settings  `json:"settings"`
+	Rules      []Rule    `json:"rules"`
+	Synonyms   []Synonym `json:"synonyms"`
+}
+
+// IndexArchiveRes reports what has been written by `Index.Export` or restored
+// by `Index.Import`.
+type IndexArchiveRes struct {
+	NbRecords  int
+	NbRules    int
+	NbSynonyms int
+}
@@ -1,5 +1,10 @@
 package algoliasearch
 
+import (
+	"context"
+	"sort"
+)
+
 type BatchOperation struct {
 	Action string      `json:"action"`
 	Body   interface{} `json:"body,omitempty"`
@@ -13,11 +18,46 @@ type BatchOperationIndexed struct {
 type BatchRes struct {
 	ObjectIDs []string `json:"objectIDs"`
 	TaskID    int      `json:"taskID"`
+	taskWaiter
+}
+
+// Wait blocks until the batch task is published on the index it originates
+// from, according to the WaitPolicy of the Client. It stops early if `ctx` is
+// done.
+func (r BatchRes) Wait(ctx context.Context) error {
+	return r.wait(ctx, r.TaskID)
 }
 
 type MultipleBatchRes struct {
 	ObjectIDs []string       `json:"objectIDs"`
 	TaskID    map[string]int `json:"taskID"`
+	taskWaiter
+}
+
+// Wait blocks until the tasks created by the batch on every targeted index
+// are published, as `Client.WaitTasks` does. It stops early if `ctx` is done.
+func (r MultipleBatchRes) Wait(ctx context.Context) error {
+	if r.client == nil {
+		return NotAwaitableTaskErr
+	}
+	return r.client.waitTasks(ctx, r.Tasks(), r.opts)
+}
+
+// Tasks returns the tasks created by the batch, one per targeted index and
+// sorted by index name, so that they can be waited for with
+// `Client.WaitTasks`.
+func (r MultipleBatchRes) Tasks() []IndexedTask {
+	indexNames := make([]string, 0, len(r.TaskID))
+	for indexName := range r.TaskID {
+		indexNames = append(indexNames, indexName)
+	}
+	sort.Strings(indexNames)
+
+	tasks := make([]IndexedTask, len(indexNames))
+	for i, indexName := range indexNames {
+		tasks[i] = IndexedTask{IndexName: indexName, TaskID: r.TaskID[indexName]}
+	}
+	return tasks
 }
 
 func newBatchOperations(objects []Object, action string) (operations []BatchOperation, err error) {
@@ -1,7 +1,79 @@
 package algoliasearch
 
+import (
+	"fmt"
+	"strconv"
+)
+
 type BrowseRes struct {
 	Cursor  string `json:"cursor"`
 	Warning string `json:"warning"`
 	QueryRes
 }
+
+// BrowseCheckpoint identifies a position within a `BrowseAll` iteration, so
+// that the iteration can later be resumed with `Index.ResumeBrowseAll`. Cursor
+// is the browse cursor of the current page (empty for the first page) and
+// Position is the number of hits of this page which have already been
+// returned.
+type BrowseCheckpoint struct {
+	Cursor   string `json:"cursor"`
+	Position int    `json:"position"`
+}
+
+// Checkpointer can be given to `Index.ResumeBrowseAll` to persist the progress
+// of a long iteration. SaveCheckpoint is called each time a new page is loaded
+// by the iterator, once all the hits of the previous pages have been returned
+// by `Next()`.
+type Checkpointer interface {
+	SaveCheckpoint(checkpoint BrowseCheckpoint) error
+}
+
+// CheckpointerFunc is an adapter to use an ordinary function as a
+// Checkpointer.
+type CheckpointerFunc func(checkpoint BrowseCheckpoint) error
+
+// SaveCheckpoint calls f(checkpoint).
+func (f CheckpointerFunc) SaveCheckpoint(checkpoint BrowseCheckpoint) error {
+	return f(checkpoint)
+}
+
+// BrowseParallelOptions controls how `Index.BrowseParallel` splits and browses
+// an index.
+type BrowseParallelOptions struct {
+	// Filters are the partitions of the index to browse concurrently. They
+	// must be disjoint, otherwise the records matching several of them are
+	// returned several times. Each one is combined with the `filters`
+	// parameter, if any, using an AND operator. If empty, the whole index is
+	// browsed as a single partition. `NumericRangeFilters` can be used to
+	// split an index by numeric ranges.
+	Filters []string
+
+	// Workers is the maximum number of partitions browsed concurrently.
+	// Defaults to 4.
+	Workers int
+}
+
+// NumericRangeFilters returns the filters partitioning the records having a
+// numeric `attribute` according to the given increasing `bounds`. For
+// instance, the bounds 10 and 20 produce the three following filters:
+// `attribute < 10`, `attribute >= 10 AND attribute < 20` and
+// `attribute >= 20`. The records which do not have the attribute match none
+// of the filters. If no bound is given, nil is returned.
+func NumericRangeFilters(attribute string, bounds ...float64) []string {
+	format := func(bound float64) string {
+		return strconv.FormatFloat(bound, 'f', -1, 64)
+	}
+
+	if len(bounds) == 0 {
+		return nil
+	}
+
+	filters := []string{fmt.Sprintf("%s < %s", attribute, format(bounds[0]))}
+	for j := 1; j < len(bounds); j++ {
+		filters = append(filters, fmt.Sprintf("%s >= %s AND %s < %s", attribute, format(bounds[j-1]), attribute, format(bounds[j])))
+	}
+	filters = append(filters, fmt.Sprintf("%s >= %s", attribute, format(bounds[len(bounds)-1])))
+
+	return filters
+}
@@ -3,3 +3,28 @@ package algoliasearch
 type DeleteRes struct {
 	DeletedAt string `json:"deletedAt"`
 }
+
+// DeleteByQueryOptions controls how `DeleteByQueryInBatches` deletes the
+// records matching a query.
+type DeleteByQueryOptions struct {
+	// BatchSize is the maximum number of objectIDs sent in a single deletion
+	// batch. Defaults to 1000 if zero or negative.
+	BatchSize int
+
+	// DryRun, when set to true, only browses and counts the matching records
+	// without deleting anything.
+	DryRun bool
+
+	// Progress, if non-nil, is called with the progress of the deletion so far
+	// each time a batch of deletions has been sent, or after each browsed page
+	// of results during a dry run.
+	Progress func(progress DeleteByQueryRes)
+}
+
+// DeleteByQueryRes reports how many records were found and deleted by
+// `DeleteByQueryInBatches`, as well as the tasks of the deletion batches.
+type DeleteByQueryRes struct {
+	NbMatched int
+	NbDeleted int
+	TaskIDs   []int
+}
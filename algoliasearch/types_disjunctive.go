@@ -0,0 +1,31 @@
+package algoliasearch
+
+// NumericRefinement restricts the hits to the records whose numeric
+// `Attribute` compares to `Value` according to `Operator`, which is one of
+// "<", "<=", "=", "!=", ">=" or ">".
+type NumericRefinement struct {
+	Attribute string
+	Operator  string
+	Value     float64
+}
+
+// RefinementState describes the facets and refinements of a faceted
+// navigation, as used by `Index.DisjunctiveSearch`.
+type RefinementState struct {
+	// ConjunctiveFacets maps each conjunctive facet whose values should be
+	// counted to its refined values. A hit must match all the refined values
+	// of a conjunctive facet.
+	ConjunctiveFacets map[string][]string
+
+	// DisjunctiveFacets maps each disjunctive facet whose values should be
+	// counted to its refined values. A hit must match at least one of the
+	// refined values of a disjunctive facet. The counts of a disjunctive facet
+	// ignore its own refinements so that the other values can still be
+	// selected.
+	DisjunctiveFacets map[string][]string
+
+	// NumericRefinements are the numeric refinements, which must all be
+	// matched by the hits. The counts and stats of a disjunctive facet ignore
+	// the numeric refinements on the same attribute.
+	NumericRefinements []NumericRefinement
+}
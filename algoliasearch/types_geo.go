@@ -0,0 +1,159 @@
+package algoliasearch
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// GeoPoint is a geographic location, which can be used as the `aroundLatLng`
+// search parameter or as the `_geoloc` attribute of a record.
+type GeoPoint struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+}
+
+// Validate returns an error if the latitude is not within [-90, 90] or if the
+// longitude is not within [-180, 180].
+func (p GeoPoint) Validate() error {
+	if math.IsNaN(p.Lat) || p.Lat < -90 || p.Lat > 90 {
+		return fmt.Errorf("invalid latitude %v: should be within [-90, 90]", p.Lat)
+	}
+	if math.IsNaN(p.Lng) || p.Lng < -180 || p.Lng > 180 {
+		return fmt.Errorf("invalid longitude %v: should be within [-180, 180]", p.Lng)
+	}
+	return nil
+}
+
+// String returns the point as `lat,lng`, which is how it is encoded as the
+// `aroundLatLng` search parameter.
+func (p GeoPoint) String() string {
+	return formatCoordinates(p.Lat, p.Lng)
+}
+
+func (p GeoPoint) paramString() string {
+	return p.String()
+}
+
+// BoundingBox is a rectangular area defined by two opposite corners, which
+// can be used as the `insideBoundingBox` search parameter, either alone or in
+// a []BoundingBox.
+type BoundingBox struct {
+	P1 GeoPoint
+	P2 GeoPoint
+}
+
+// Validate returns an error if any of the corners is invalid.
+func (b BoundingBox) Validate() error {
+	if err := b.P1.Validate(); err != nil {
+		return err
+	}
+	return b.P2.Validate()
+}
+
+func (b BoundingBox) paramString() string {
+	return formatCoordinates(b.P1.Lat, b.P1.Lng, b.P2.Lat, b.P2.Lng)
+}
+
+// MarshalJSON encodes the bounding box as the array of its four coordinates.
+func (b BoundingBox) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]float64{b.P1.Lat, b.P1.Lng, b.P2.Lat, b.P2.Lng})
+}
+
+// Polygon is an area defined by at least three points, which can be used as
+// the `insidePolygon` search parameter, either alone or in a []Polygon.
+type Polygon []GeoPoint
+
+// Validate returns an error if the polygon has fewer than three points or if
+// any of its points is invalid.
+func (p Polygon) Validate() error {
+	if len(p) < 3 {
+		return fmt.Errorf("invalid polygon: should have at least 3 points but has %d", len(p))
+	}
+	for _, point := range p {
+		if err := point.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p Polygon) coordinates() []float64 {
+	coordinates := make([]float64, 0, 2*len(p))
+	for _, point := range p {
+		coordinates = append(coordinates, point.Lat, point.Lng)
+	}
+	return coordinates
+}
+
+func (p Polygon) paramString() string {
+	return formatCoordinates(p.coordinates()...)
+}
+
+// MarshalJSON encodes the polygon as the flat array of the coordinates of its
+// points.
+func (p Polygon) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.coordinates())
+}
+
+// Radius is the maximum distance, in meters, around the searched location,
+// which can be used as the `aroundRadius` search parameter. RadiusAll
+// disables the radius.
+type Radius struct {
+	meters int
+	all    bool
+}
+
+// RadiusAll is the Radius which disables the filtering by distance, while
+// still ranking the hits by distance.
+var RadiusAll = Radius{all: true}
+
+// NewRadius returns a Radius of the given number of `meters`.
+func NewRadius(meters int) Radius {
+	return Radius{meters: meters}
+}
+
+// Meters returns the radius in meters and false if the radius is RadiusAll.
+func (r Radius) Meters() (meters int, ok bool) {
+	return r.meters, !r.all
+}
+
+// Validate returns an error if the radius is not RadiusAll and not strictly
+// positive.
+func (r Radius) Validate() error {
+	if !r.all && r.meters <= 0 {
+		return fmt.Errorf("invalid radius %d: should be strictly positive", r.meters)
+	}
+	return nil
+}
+
+func (r Radius) paramString() string {
+	if r.all {
+		return "all"
+	}
+	return strconv.Itoa(r.meters)
+}
+
+// MarshalJSON encodes the radius as a number of meters or as "all".
+func (r Radius) MarshalJSON() ([]byte, error) {
+	if r.all {
+		return json.Marshal("all")
+	}
+	return json.Marshal(r.meters)
+}
+
+// paramStringer is implemented by the parameter types, such as GeoPoint, which
+// are encoded into the params string with a specific format instead of JSON.
+type paramStringer interface {
+	paramString() string
+}
+
+func formatCoordinates(coordinates ...float64) string {
+	formatted := make([]string, len(coordinates))
+	for i, c := range coordinates {
+		formatted[i] = strconv.FormatFloat(c, 'f', -1, 64)
+	}
+	return strings.Join(formatted, ",")
+}
@@ -12,7 +12,7 @@ type IndexRes struct {
 	UpdatedAt            string `json:"updatedAt"`
 }
 
-type listIndexesRes struct {
+type ListIndexesRes struct {
 	Items   []IndexRes `json:"items"`
 	NbPages int        `json:"nbPages"`
 }
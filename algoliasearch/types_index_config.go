@@ -0,0 +1,91 @@
+package algoliasearch
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Names of the files, without their extension, holding the configuration of
+// an index in the directories read by `LoadIndexConfigs`.
+const (
+	IndexConfigSettingsFile = "settings"
+	IndexConfigRulesFile    = "rules"
+	IndexConfigSynonymsFile = "synonyms"
+)
+
+// IndexConfig is the configuration of an index, as loaded by
+// `LoadIndexConfigs`. Each part of the configuration is optional: a nil part
+// is not managed and is left untouched on the index, whereas an empty, but
+// non-nil, list of Rules or synonyms removes all of them from the index.
+type IndexConfig struct {
+	IndexName string
+	Settings  *Settings
+	Rules     []Rule
+	Synonyms  []Synonym
+}
+
+// LoadIndexConfigOptions controls how `LoadIndexConfigs` reads the
+// configuration files.
+type LoadIndexConfigOptions struct {
+	// Converters maps file extensions, such as ".toml", to functions which
+	// convert the content of such files to JSON. This lets configuration
+	// files be written in other formats than JSON (".json") and YAML
+	// (".yaml" and ".yml"), which are always supported. A converter given
+	// for ".yaml" or ".yml" replaces the built-in one.
+	Converters map[string]func(data []byte) ([]byte, error)
+}
+
+// IndexConfigPlan lists the changes needed to make an index match its
+// IndexConfig, as computed by `PlanIndexConfig`.
+type IndexConfigPlan struct {
+	IndexName string `json:"indexName"`
+
+	Settings SettingsDiff `json:"settings"`
+
+	// RulesToSave are the Rules which are either missing from the index or
+	// different, while RulesToDelete are the objectIDs of the Rules which are
+	// on the index but not in the configuration.
+	RulesToSave   []Rule   `json:"rulesToSave"`
+	RulesToDelete []string `json:"rulesToDelete"`
+
+	// SynonymsToSave and SynonymsToDelete are the same as RulesToSave and
+	// RulesToDelete for the synonyms.
+	SynonymsToSave   []Synonym `json:"synonymsToSave"`
+	SynonymsToDelete []string  `json:"synonymsToDelete"`
+
+	config IndexConfig
+}
+
+// IsEmpty returns true if the index already matches its configuration.
+func (p IndexConfigPlan) IsEmpty() bool {
+	return len(p.Settings) == 0 &&
+		len(p.RulesToSave) == 0 && len(p.RulesToDelete) == 0 &&
+		len(p.SynonymsToSave) == 0 && len(p.SynonymsToDelete) == 0
+}
+
+// String returns a human-readable summary of the plan, one change per line.
+func (p IndexConfigPlan) String() string {
+	lines := []string{fmt.Sprintf("index %s:", p.IndexName)}
+
+	for _, change := range p.Settings {
+		lines = append(lines, "  ~ settings "+change.String())
+	}
+	for _, rule := range p.RulesToSave {
+		lines = append(lines, "  + rule "+rule.ObjectID)
+	}
+	for _, objectID := range p.RulesToDelete {
+		lines = append(lines, "  - rule "+objectID)
+	}
+	for _, synonym := range p.SynonymsToSave {
+		lines = append(lines, "  + synonym "+synonym.ObjectID)
+	}
+	for _, objectID := range p.SynonymsToDelete {
+		lines = append(lines, "  - synonym "+objectID)
+	}
+
+	if p.IsEmpty() {
+		lines = append(lines, "  no change")
+	}
+
+	return strings.Join(lines, "\n")
+}
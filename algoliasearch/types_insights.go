@@ -0,0 +1,53 @@
+package algoliasearch
+
+import "time"
+
+const (
+	// InsightsClickEvent, InsightsConversionEvent and InsightsViewEvent are
+	// the types of the events sent to the Algolia Insights API.
+	InsightsClickEvent      = "click"
+	InsightsConversionEvent = "conversion"
+	InsightsViewEvent       = "view"
+)
+
+// InsightsEvent is an event sent to the Algolia Insights API. An event is
+// related to either a list of records (ObjectIDs) or a list of filters
+// (Filters), but not both. The methods of UserInsights build the events
+// expected by the API for each kind of user interaction.
+type InsightsEvent struct {
+	EventType string   `json:"eventType"`
+	EventName string   `json:"eventName"`
+	Index     string   `json:"index"`
+	UserToken string   `json:"userToken"`
+	Timestamp int64    `json:"timestamp,omitempty"`
+	QueryID   string   `json:"queryID,omitempty"`
+	ObjectIDs []string `json:"objectIDs,omitempty"`
+	Positions []int    `json:"positions,omitempty"`
+	Filters   []string `json:"filters,omitempty"`
+}
+
+type InsightsRes struct {
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+}
+
+// InsightsBufferOptions controls when an InsightsBuffer sends its events.
+type InsightsBufferOptions struct {
+	// MaxEvents is the number of buffered events which triggers a flush.
+	// Defaults to 1000, the maximum number of events per request.
+	MaxEvents int
+
+	// FlushInterval is the maximum duration during which events are kept in
+	// the buffer. A zero value disables the periodic flush.
+	FlushInterval time.Duration
+
+	// MaxBufferedEvents caps the number of events kept in the buffer while
+	// they cannot be sent because of network or server errors. Once
+	// exceeded, the oldest events are dropped. Defaults to 10 times
+	// MaxEvents, and cannot be lower than MaxEvents.
+	MaxBufferedEvents int
+
+	// OnError, if non-nil, is called with the error of the flushes which are
+	// not triggered by an explicit call to Flush or Close.
+	OnError func(err error)
+}
@@ -1,6 +1,7 @@
 package algoliasearch
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 )
@@ -9,12 +10,28 @@ type CreateObjectRes struct {
 	CreatedAt string `json:"createdAt"`
 	ObjectID  string `json:"objectID"`
 	TaskID    int    `json:"taskID"`
+	taskWaiter
+}
+
+// Wait blocks until the creation task is published on the index it originates
+// from, according to the WaitPolicy of the Client. It stops early if `ctx` is
+// done.
+func (r CreateObjectRes) Wait(ctx context.Context) error {
+	return r.wait(ctx, r.TaskID)
 }
 
 type UpdateObjectRes struct {
 	ObjectID  string `json:"objectID"`
 	TaskID    int    `json:"taskID"`
 	UpdatedAt string `json:"updatedAt"`
+	taskWaiter
+}
+
+// Wait blocks until the update task is published on the index it originates
+// from, according to the WaitPolicy of the Client. It stops early if `ctx` is
+// done.
+func (r UpdateObjectRes) Wait(ctx context.Context) error {
+	return r.wait(ctx, r.TaskID)
 }
 
 type objects struct {
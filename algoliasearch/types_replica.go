@@ -0,0 +1,105 @@
+package algoliasearch
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Replica declares a replica of a primary index, as managed by
+// `Index.SyncReplicas`.
+type Replica struct {
+	// Name is the name of the replica index.
+	Name string
+
+	// Sort, if non-empty, holds the sort criteria of the replica, such as
+	// `desc(price)`. They are set before the ranking criteria of the primary
+	// in the `ranking` of the replica, whose other criteria are kept in sync
+	// with the primary.
+	Sort []string
+
+	// Settings, if non-nil, are extra settings of the replica which take
+	// precedence over the settings propagated from the primary. Their nil
+	// fields are not managed. The `replicas`, `slaves` and `primary` settings
+	// cannot be set, and neither can `ranking` if `Sort` is set.
+	Settings *Settings
+}
+
+// ReplicasConfig is the declarative configuration of the replicas of a
+// primary index, as used by `Index.SyncReplicas` and
+// `Index.GetReplicasDrift`.
+type ReplicasConfig struct {
+	// Replicas are all the replicas of the primary. Replicas of the primary
+	// which are not declared are detached from it.
+	Replicas []Replica
+
+	// PropagatedSettings are the names of the settings, such as
+	// `searchableAttributes`, whose values are copied from the primary to
+	// each replica. Settings which are not set on the primary are reset on
+	// the replicas. The names must be known settings, and the `ranking`,
+	// `replicas`, `slaves` and `primary` settings cannot be propagated.
+	PropagatedSettings []string
+
+	// DeleteRemoved, if set, deletes the indices which have been detached
+	// from the primary instead of keeping them as regular indices.
+	DeleteRemoved bool
+}
+
+// ReplicasDrift reports how the replicas of a primary index differ from
+// their ReplicasConfig.
+type ReplicasDrift struct {
+	// Missing are the declared replicas which are not replicas of the
+	// primary.
+	Missing []string
+
+	// Extra are the replicas of the primary which are not declared.
+	Extra []string
+
+	// Settings holds, for each declared replica whose settings differ from
+	// the expected ones, the changes from its current settings to the
+	// expected ones. The settings of the missing replicas are not compared.
+	Settings map[string]SettingsDiff
+}
+
+// IsEmpty returns true if the replicas match their configuration.
+func (d ReplicasDrift) IsEmpty() bool {
+	return len(d.Missing) == 0 && len(d.Extra) == 0 && len(d.Settings) == 0
+}
+
+// String returns a human-readable summary of the drift, one line per missing
+// or extra replica and per changed setting. It returns an empty string if
+// there is no drift.
+func (d ReplicasDrift) String() string {
+	var lines []string
+
+	for _, name := range d.Missing {
+		lines = append(lines, fmt.Sprintf("+ replica %s", name))
+	}
+	for _, name := range d.Extra {
+		lines = append(lines, fmt.Sprintf("- replica %s", name))
+	}
+
+	var names []string
+	for name := range d.Settings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, change := range d.Settings[name] {
+			lines = append(lines, fmt.Sprintf("~ replica %s %s", name, change))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// SyncReplicasRes reports what `Index.SyncReplicas` has changed.
+type SyncReplicasRes struct {
+	// Drift is the drift which has been fixed. The settings of the missing
+	// replicas are compared once they have been attached to the primary.
+	Drift ReplicasDrift
+
+	// Deleted are the detached replicas which have been deleted, if
+	// `ReplicasConfig.DeleteRemoved` is set.
+	Deleted []string
+}
@@ -1,6 +1,7 @@
 package algoliasearch
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"time"
@@ -150,21 +151,53 @@ type HiddenObject struct {
 type SaveRuleRes struct {
 	TaskID    int    `json:"taskID"`
 	UpdatedAt string `json:"updatedAt"`
+	taskWaiter
+}
+
+// Wait blocks until the task saving the Rule is published on the index it
+// originates from, according to the WaitPolicy of the Client. It stops early
+// if `ctx` is done.
+func (r SaveRuleRes) Wait(ctx context.Context) error {
+	return r.wait(ctx, r.TaskID)
 }
 
 type BatchRulesRes struct {
 	TaskID    int    `json:"taskID"`
 	UpdatedAt string `json:"updatedAt"`
+	taskWaiter
+}
+
+// Wait blocks until the task saving the Rules is published on the index it
+// originates from, according to the WaitPolicy of the Client. It stops early
+// if `ctx` is done.
+func (r BatchRulesRes) Wait(ctx context.Context) error {
+	return r.wait(ctx, r.TaskID)
 }
 
 type DeleteRuleRes struct {
 	TaskID    int    `json:"taskID"`
 	UpdatedAt string `json:"updatedAt"`
+	taskWaiter
+}
+
+// Wait blocks until the task deleting the Rule is published on the index it
+// originates from, according to the WaitPolicy of the Client. It stops early
+// if `ctx` is done.
+func (r DeleteRuleRes) Wait(ctx context.Context) error {
+	return r.wait(ctx, r.TaskID)
 }
 
 type ClearRulesRes struct {
 	TaskID    int    `json:"taskID"`
 	UpdatedAt string `json:"updatedAt"`
+	taskWaiter
+}
+
+// Wait blocks until the task clearing the Rules is published on the index it
+// originates from, according to the WaitPolicy of the Client. It stops early
+// if `ctx` is done.
+func (r ClearRulesRes) Wait(ctx context.Context) error {
+	return r.wait(ctx, r.TaskID)
 }
 
 type TimeRange struct {
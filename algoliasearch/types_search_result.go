@@ -0,0 +1,59 @@
+package algoliasearch
+
+const (
+	// DefaultHighlightPreTag and DefaultHighlightPostTag are the tags
+	// surrounding the highlighted parts of the highlight and snippet results
+	// when the `highlightPreTag` and `highlightPostTag` parameters are not
+	// set.
+	DefaultHighlightPreTag  = "<em>"
+	DefaultHighlightPostTag = "</em>"
+)
+
+// FacetStats are the statistics of the values of a numeric facet, as found in
+// the `facets_stats` of a QueryRes.
+type FacetStats struct {
+	Min float64 `json:"min"`
+	Max float64 `json:"max"`
+	Avg float64 `json:"avg"`
+	Sum float64 `json:"sum"`
+}
+
+// HighlightResult is the highlighted value of an attribute of a hit, as found
+// in its `_highlightResult`.
+type HighlightResult struct {
+	Value            string   `json:"value"`
+	MatchLevel       string   `json:"matchLevel"`
+	MatchedWords     []string `json:"matchedWords"`
+	FullyHighlighted bool     `json:"fullyHighlighted"`
+}
+
+// SnippetResult is the snippet of an attribute of a hit, as found in its
+// `_snippetResult`.
+type SnippetResult struct {
+	Value      string `json:"value"`
+	MatchLevel string `json:"matchLevel"`
+}
+
+// RankingInfo is the ranking information of a hit, as found in its
+// `_rankingInfo` when the `getRankingInfo` parameter is enabled.
+type RankingInfo struct {
+	Filters            int                 `json:"filters"`
+	FirstMatchedWord   int                 `json:"firstMatchedWord"`
+	GeoDistance        int                 `json:"geoDistance"`
+	GeoPrecision       int                 `json:"geoPrecision"`
+	MatchedGeoLocation *MatchedGeoLocation `json:"matchedGeoLocation,omitempty"`
+	NbExactWords       int                 `json:"nbExactWords"`
+	NbTypos            int                 `json:"nbTypos"`
+	Promoted           bool                `json:"promoted"`
+	ProximityDistance  int                 `json:"proximityDistance"`
+	UserScore          int                 `json:"userScore"`
+	Words              int                 `json:"words"`
+}
+
+// MatchedGeoLocation is the geo location of a hit which matched a geo search,
+// along with its distance (in meters) to the searched location.
+type MatchedGeoLocation struct {
+	Lat      float64 `json:"lat"`
+	Lng      float64 `json:"lng"`
+	Distance int     `json:"distance"`
+}
@@ -1,236 +1,216 @@
 package algoliasearch
 
-import (
-	"fmt"
-	"os"
-	"reflect"
-)
-
-// Settings is the structure returned by `GetSettigs` to ease the use of the
-// index settings.
+// Settings is the structure returned by `GetSettings` and accepted by
+// `SetTypedSettings` to ease the use of the index settings.
+//
+// A nil field is a setting which is not set: it is neither returned by the
+// API nor sent to it. Scalar settings are therefore pointers, which can be
+// built with the `Bool`, `Int` and `String` helpers, and slices must be empty
+// but non-nil, such as `[]string{}`, to be reset. Settings which accept
+// several types are `interface{}` values whose expected types are documented
+// next to them. Nil slices are encoded as JSON `null` so that the Settings
+// also survive a JSON round trip without any loss.
 type Settings struct {
 	// Indexing parameters
-	AllowCompressionOfIntegerArray bool                `json:"allowCompressionOfIntegerArray"`
-	AttributeForDistinct           string              `json:"attributeForDistinct"`
+	AllowCompressionOfIntegerArray *bool               `json:"allowCompressionOfIntegerArray,omitempty"`
+	AttributeForDistinct           *string             `json:"attributeForDistinct,omitempty"`
 	AttributesForFaceting          []string            `json:"attributesForFaceting"`
 	AttributesToIndex              []string            `json:"attributesToIndex"`
 	CamelCaseAttributes            []string            `json:"camelCaseAttributes"`
 	CustomRanking                  []string            `json:"customRanking"`
 	DecompoundedAttributes         map[string][]string `json:"decompoundedAttributes"`
-	KeepDiacriticsOnCharacters     string              `json:"keepDiacriticsOnCharacters"`
+	IndexLanguages                 []string            `json:"indexLanguages"`
+	KeepDiacriticsOnCharacters     *string             `json:"keepDiacriticsOnCharacters,omitempty"`
 	NumericAttributesForFiltering  []string            `json:"numericAttributesForFiltering"`
 	NumericAttributesToIndex       []string            `json:"numericAttributesToIndex"`
-	PaginationLimitedTo            int                 `json:"paginationLimitedTo"`
+	PaginationLimitedTo            *int                `json:"paginationLimitedTo,omitempty"`
+	Primary                        *string             `json:"primary,omitempty"` // Read-only: never sent by `ToMap`
 	Ranking                        []string            `json:"ranking"`
 	Replicas                       []string            `json:"replicas"`
 	SearchableAttributes           []string            `json:"searchableAttributes"`
-	SeparatorsToIndex              string              `json:"separatorsToIndex"`
+	SeparatorsToIndex              *string             `json:"separatorsToIndex,omitempty"`
 	Slaves                         []string            `json:"slaves"`
 	UnretrievableAttributes        []string            `json:"unretrievableAttributes"`
+	UserData                       interface{}         `json:"userData,omitempty"`
 
 	// Query expansion
 	DisableTypoToleranceOnAttributes []string `json:"disableTypoToleranceOnAttributes"`
 	DisableTypoToleranceOnWords      []string `json:"disableTypoToleranceOnWords"`
 
 	// Default query parameters (can be overridden at query-time)
-	AdvancedSyntax                    bool        `json:"advancedSyntax"`
-	AllowTyposOnNumericTokens         bool        `json:"allowTyposOnNumericTokens"`
-	AttributesToHighlight             []string    `json:"attributesToHighlight"`
-	AttributesToRetrieve              []string    `json:"attributesToRetrieve"`
-	AttributesToSnippet               []string    `json:"attributesToSnippet"`
-	Distinct                          interface{} `json:"distinct"` // float64 (actually an int) or bool
-	EnableRules                       bool        `json:"enableRules"`
-	HighlightPostTag                  string      `json:"highlightPostTag"`
-	HighlightPreTag                   string      `json:"highlightPreTag"`
-	HitsPerPage                       int         `json:"hitsPerPage"`
-	IgnorePlurals                     interface{} `json:"ignorePlurals"` // []interface{} (actually a []string) or bool
-	MaxFacetHits                      int         `json:"maxFacetHits"`
-	MaxValuesPerFacet                 int         `json:"maxValuesPerFacet"`
-	MinProximity                      int         `json:"minProximity"`
-	MinWordSizefor1Typo               int         `json:"minWordSizefor1Typo"`
-	MinWordSizefor2Typos              int         `json:"minWordSizefor2Typos"`
-	OptionalWords                     []string    `json:"optionalWords"`
-	QueryType                         string      `json:"queryType"`
-	RemoveStopWords                   interface{} `json:"removeStopWords"` // []interface{} (actually a []string) or bool
-	QueryLanguages                    []string    `json:"queryLanguages"`
-	RemoveWordsIfNoResults            string      `json:"removeWordsIfNoResults"`
-	ReplaceSynonymsInHighlight        bool        `json:"replaceSynonymsInHighlight"`
-	ResponseFields                    []string    `json:"responseFields"`
-	RestrictHighlightAndSnippetArrays bool        `json:"restrictHighlightAndSnippetArrays"`
-	SnippetEllipsisText               string      `json:"snippetEllipsisText"`
-	SortFacetValuesBy                 string      `json:"sortFacetValuesBy"`
-	TypoTolerance                     string      `json:"typoTolerance"`
+	AdvancedSyntax                          *bool       `json:"advancedSyntax,omitempty"`
+	AdvancedSyntaxFeatures                  []string    `json:"advancedSyntaxFeatures"`
+	AllowTyposOnNumericTokens               *bool       `json:"allowTyposOnNumericTokens,omitempty"`
+	AlternativesAsExact                     []string    `json:"alternativesAsExact"`
+	AttributeCriteriaComputedByMinProximity *bool       `json:"attributeCriteriaComputedByMinProximity,omitempty"`
+	AttributesToHighlight                   []string    `json:"attributesToHighlight"`
+	AttributesToRetrieve                    []string    `json:"attributesToRetrieve"`
+	AttributesToSnippet                     []string    `json:"attributesToSnippet"`
+	DisableExactOnAttributes                []string    `json:"disableExactOnAttributes"`
+	DisablePrefixOnAttributes               []string    `json:"disablePrefixOnAttributes"`
+	Distinct                                interface{} `json:"distinct,omitempty"` // int (float64 once decoded) or bool
+	EnablePersonalization                   *bool       `json:"enablePersonalization,omitempty"`
+	EnableRules                             *bool       `json:"enableRules,omitempty"`
+	ExactOnSingleWordQuery                  *string     `json:"exactOnSingleWordQuery,omitempty"`
+	HighlightPostTag                        *string     `json:"highlightPostTag,omitempty"`
+	HighlightPreTag                         *string     `json:"highlightPreTag,omitempty"`
+	HitsPerPage                             *int        `json:"hitsPerPage,omitempty"`
+	IgnorePlurals                           interface{} `json:"ignorePlurals,omitempty"` // []string ([]interface{} once decoded) or bool
+	MaxFacetHits                            *int        `json:"maxFacetHits,omitempty"`
+	MaxValuesPerFacet                       *int        `json:"maxValuesPerFacet,omitempty"`
+	MinProximity                            *int        `json:"minProximity,omitempty"`
+	MinWordSizefor1Typo                     *int        `json:"minWordSizefor1Typo,omitempty"`
+	MinWordSizefor2Typos                    *int        `json:"minWordSizefor2Typos,omitempty"`
+	OptionalWords                           []string    `json:"optionalWords"`
+	QueryLanguages                          []string    `json:"queryLanguages"`
+	QueryType                               *string     `json:"queryType,omitempty"`
+	RemoveStopWords                         interface{} `json:"removeStopWords,omitempty"` // []string ([]interface{} once decoded) or bool
+	RemoveWordsIfNoResults                  *string     `json:"removeWordsIfNoResults,omitempty"`
+	ReplaceSynonymsInHighlight              *bool       `json:"replaceSynonymsInHighlight,omitempty"`
+	ResponseFields                          []string    `json:"responseFields"`
+	RestrictHighlightAndSnippetArrays       *bool       `json:"restrictHighlightAndSnippetArrays,omitempty"`
+	SnippetEllipsisText                     *string     `json:"snippetEllipsisText,omitempty"`
+	SortFacetValuesBy                       *string     `json:"sortFacetValuesBy,omitempty"`
+	TypoTolerance                           interface{} `json:"typoTolerance,omitempty"` // string or bool
 }
 
-// clean sets the nil `interface{}` fields of any `Settings struct` generated
-// by `GetSettings`.
-func (s *Settings) clean() {
-	if s.Distinct == nil {
-		s.Distinct = false
-	}
-
-	if s.IgnorePlurals == nil {
-		s.IgnorePlurals = false
-	}
-
-	if s.RemoveStopWords == nil {
-		s.RemoveStopWords = false
-	}
-
-	if s.TypoTolerance == "" {
-		s.TypoTolerance = "true"
-	}
-
-	if s.MaxFacetHits == 0 {
-		s.MaxFacetHits = 10
+// Bool returns a pointer to the given bool, to be used as a Settings field.
+func Bool(b bool) *bool { return &b }
+
+// Int returns a pointer to the given int, to be used as a Settings field.
+func Int(i int) *int { return &i }
+
+// String returns a pointer to the given string, to be used as a Settings
+// field.
+func String(s string) *string { return &s }
+
+// ToMap produces a `Map` corresponding to the `Settings struct`, as expected
+// by `SetSettings`. Only the non-nil fields are present in the `Map`, except
+// for the read-only `Primary` one, so that the settings returned by
+// `GetSettings` can be sent back without any loss.
+//
+// The fields which accept several types are converted to the types expected
+// by `SetSettings` when they have been decoded from JSON (`float64` to `int`
+// and `[]interface{}` to `[]string`). Values of any other type are kept as-is
+// so that `SetSettings` reports them as invalid.
+func (s Settings) ToMap() Map {
+	m := Map{}
+
+	setBool := func(k string, v *bool) {
+		if v != nil {
+			m[k] = *v
+		}
 	}
-
-	if s.SortFacetValuesBy == "" {
-		s.SortFacetValuesBy = "count"
+	setInt := func(k string, v *int) {
+		if v != nil {
+			m[k] = *v
+		}
 	}
-}
-
-// ToMap produces a `Map` corresponding to the `Settings struct`. It should
-// only be used when it's needed to pass a `Settings struct` to `SetSettings`,
-// typically when one needs to copy settings between two indices.
-func (s *Settings) ToMap() Map {
-	// Guarantee that interface{}-typed fields and default values are correctly
-	// set.
-	s.clean()
-
-	// Add all fields except:
-	//  - Distinct float64 or bool
-	//  - IgnorePlurals []interface{} or bool
-	//  - RemoveStopWords []interface{} or bool
-	m := Map{
-		// Indexing parameters
-		"allowCompressionOfIntegerArray": s.AllowCompressionOfIntegerArray,
-		"attributeForDistinct":           s.AttributeForDistinct,
-		"attributesForFaceting":          s.AttributesForFaceting,
-		"attributesToIndex":              s.AttributesToIndex,
-		"camelCaseAttributes":            s.CamelCaseAttributes,
-		"customRanking":                  s.CustomRanking,
-		"decompoundedAttributes":         s.DecompoundedAttributes,
-		"keepDiacriticsOnCharacters":     s.KeepDiacriticsOnCharacters,
-		"numericAttributesForFiltering":  s.NumericAttributesForFiltering,
-		"numericAttributesToIndex":       s.NumericAttributesToIndex,
-		"paginationLimitedTo":            s.PaginationLimitedTo,
-		"ranking":                        s.Ranking,
-		"replicas":                       s.Replicas,
-		"searchableAttributes":           s.SearchableAttributes,
-		"separatorsToIndex":              s.SeparatorsToIndex,
-		"slaves":                         s.Slaves,
-		"unretrievableAttributes":        s.UnretrievableAttributes,
-
-		// Query expansion
-		"disableTypoToleranceOnAttributes": s.DisableTypoToleranceOnAttributes,
-		"disableTypoToleranceOnWords":      s.DisableTypoToleranceOnWords,
-
-		// Default query parameters (can be overridden at query-time)
-		"advancedSyntax":                    s.AdvancedSyntax,
-		"allowTyposOnNumericTokens":         s.AllowTyposOnNumericTokens,
-		"attributesToHighlight":             s.AttributesToHighlight,
-		"attributesToRetrieve":              s.AttributesToRetrieve,
-		"attributesToSnippet":               s.AttributesToSnippet,
-		"enableRules":                       s.EnableRules,
-		"highlightPostTag":                  s.HighlightPostTag,
-		"highlightPreTag":                   s.HighlightPreTag,
-		"hitsPerPage":                       s.HitsPerPage,
-		"ignorePlurals":                     s.IgnorePlurals,
-		"maxFacetHits":                      s.MaxFacetHits,
-		"maxValuesPerFacet":                 s.MaxValuesPerFacet,
-		"minProximity":                      s.MinProximity,
-		"minWordSizefor1Typo":               s.MinWordSizefor1Typo,
-		"minWordSizefor2Typos":              s.MinWordSizefor2Typos,
-		"optionalWords":                     s.OptionalWords,
-		"queryLanguages":                    s.QueryLanguages,
-		"queryType":                         s.QueryType,
-		"removeWordsIfNoResults":            s.RemoveWordsIfNoResults,
-		"replaceSynonymsInHighlight":        s.ReplaceSynonymsInHighlight,
-		"responseFields":                    s.ResponseFields,
-		"restrictHighlightAndSnippetArrays": s.RestrictHighlightAndSnippetArrays,
-		"snippetEllipsisText":               s.SnippetEllipsisText,
-		"sortFacetValuesBy":                 s.SortFacetValuesBy,
-		"typoTolerance":                     s.TypoTolerance,
+	setString := func(k string, v *string) {
+		if v != nil {
+			m[k] = *v
+		}
 	}
-
-	// Remove empty string slices to avoid creating null-valued fields in the
-	// JSON settings sent to the API
-	var sliceAttributesToRemove []string
-
-	for attr, value := range m {
-		switch v := value.(type) {
-		case []string:
-			if len(v) == 0 {
-				sliceAttributesToRemove = append(sliceAttributesToRemove, attr)
-			}
+	setStrings := func(k string, v []string) {
+		if v != nil {
+			m[k] = v
 		}
 	}
-
-	for _, attr := range sliceAttributesToRemove {
-		delete(m, attr)
+	setInterface := func(k string, v interface{}) {
+		if v != nil {
+			m[k] = v
+		}
 	}
 
-	// Handle `Distinct` separately as it may be either a `bool` or a `float64`
-	// which is in fact a `int`.
-	switch v := s.Distinct.(type) {
-	case bool:
-		m["distinct"] = v
-	case float64:
-		m["distinct"] = int(v)
+	// Indexing parameters
+	setBool("allowCompressionOfIntegerArray", s.AllowCompressionOfIntegerArray)
+	setString("attributeForDistinct", s.AttributeForDistinct)
+	setStrings("attributesForFaceting", s.AttributesForFaceting)
+	setStrings("attributesToIndex", s.AttributesToIndex)
+	setStrings("camelCaseAttributes", s.CamelCaseAttributes)
+	setStrings("customRanking", s.CustomRanking)
+	if s.DecompoundedAttributes != nil {
+		m["decompoundedAttributes"] = s.DecompoundedAttributes
 	}
+	setStrings("indexLanguages", s.IndexLanguages)
+	setString("keepDiacriticsOnCharacters", s.KeepDiacriticsOnCharacters)
+	setStrings("numericAttributesForFiltering", s.NumericAttributesForFiltering)
+	setStrings("numericAttributesToIndex", s.NumericAttributesToIndex)
+	setInt("paginationLimitedTo", s.PaginationLimitedTo)
+	setStrings("ranking", s.Ranking)
+	setStrings("replicas", s.Replicas)
+	setStrings("searchableAttributes", s.SearchableAttributes)
+	setString("separatorsToIndex", s.SeparatorsToIndex)
+	setStrings("slaves", s.Slaves)
+	setStrings("unretrievableAttributes", s.UnretrievableAttributes)
+	setInterface("userData", s.UserData)
 
-	// Handle `IgnorePlurals` separately as it may be either a `bool` or a
-	// `[]interface{}` which is in fact a `[]string`.
-	switch v := s.IgnorePlurals.(type) {
-
-	case bool:
-		m["ignorePlurals"] = v
+	// Query expansion
+	setStrings("disableTypoToleranceOnAttributes", s.DisableTypoToleranceOnAttributes)
+	setStrings("disableTypoToleranceOnWords", s.DisableTypoToleranceOnWords)
 
-	case []interface{}:
-		var languages []string
-		for _, itf := range v {
-			lang, ok := itf.(string)
-			if ok {
-				languages = append(languages, lang)
-			} else {
-				fmt.Fprintln(os.Stderr, "Settings.ToMap(): `ignorePlurals` slice doesn't only contain strings")
-			}
-		}
-		if len(languages) > 0 {
-			m["ignorePlurals"] = languages
-		}
+	// Default query parameters (can be overridden at query-time)
+	setBool("advancedSyntax", s.AdvancedSyntax)
+	setStrings("advancedSyntaxFeatures", s.AdvancedSyntaxFeatures)
+	setBool("allowTyposOnNumericTokens", s.AllowTyposOnNumericTokens)
+	setStrings("alternativesAsExact", s.AlternativesAsExact)
+	setBool("attributeCriteriaComputedByMinProximity", s.AttributeCriteriaComputedByMinProximity)
+	setStrings("attributesToHighlight", s.AttributesToHighlight)
+	setStrings("attributesToRetrieve", s.AttributesToRetrieve)
+	setStrings("attributesToSnippet", s.AttributesToSnippet)
+	setStrings("disableExactOnAttributes", s.DisableExactOnAttributes)
+	setStrings("disablePrefixOnAttributes", s.DisablePrefixOnAttributes)
+	setInterface("distinct", normalizeDistinct(s.Distinct))
+	setBool("enablePersonalization", s.EnablePersonalization)
+	setBool("enableRules", s.EnableRules)
+	setString("exactOnSingleWordQuery", s.ExactOnSingleWordQuery)
+	setString("highlightPostTag", s.HighlightPostTag)
+	setString("highlightPreTag", s.HighlightPreTag)
+	setInt("hitsPerPage", s.HitsPerPage)
+	setInterface("ignorePlurals", normalizeLanguages(s.IgnorePlurals))
+	setInt("maxFacetHits", s.MaxFacetHits)
+	setInt("maxValuesPerFacet", s.MaxValuesPerFacet)
+	setInt("minProximity", s.MinProximity)
+	setInt("minWordSizefor1Typo", s.MinWordSizefor1Typo)
+	setInt("minWordSizefor2Typos", s.MinWordSizefor2Typos)
+	setStrings("optionalWords", s.OptionalWords)
+	setStrings("queryLanguages", s.QueryLanguages)
+	setString("queryType", s.QueryType)
+	setInterface("removeStopWords", normalizeLanguages(s.RemoveStopWords))
+	setString("removeWordsIfNoResults", s.RemoveWordsIfNoResults)
+	setBool("replaceSynonymsInHighlight", s.ReplaceSynonymsInHighlight)
+	setStrings("responseFields", s.ResponseFields)
+	setBool("restrictHighlightAndSnippetArrays", s.RestrictHighlightAndSnippetArrays)
+	setString("snippetEllipsisText", s.SnippetEllipsisText)
+	setString("sortFacetValuesBy", s.SortFacetValuesBy)
+	setInterface("typoTolerance", s.TypoTolerance)
 
-	default:
-		fmt.Fprintf(os.Stderr, "Settings.ToMap(): Wrong type for `ignorePlurals`: %v\n", reflect.TypeOf(s.IgnorePlurals))
+	return m
+}
 
+// normalizeDistinct converts the `distinct` setting to an `int` if it has
+// been decoded from JSON as a `float64`.
+func normalizeDistinct(v interface{}) interface{} {
+	if f, ok := v.(float64); ok && f == float64(int(f)) {
+		return int(f)
 	}
+	return v
+}
 
-	// Handle `RemoveStopWords` separately as it may be either a `bool` or a
-	// `[]interface{}` which is in fact a `[]string`.
-	switch v := s.RemoveStopWords.(type) {
-
-	case bool:
-		m["removeStopWords"] = v
+// normalizeLanguages converts the `ignorePlurals` and `removeStopWords`
+// settings to a `[]string` if they have been decoded from JSON as a
+// `[]interface{}` only holding strings.
+func normalizeLanguages(v interface{}) interface{} {
+	itfs, ok := v.([]interface{})
+	if !ok {
+		return v
+	}
 
-	case []interface{}:
-		var languages []string
-		for _, itf := range v {
-			lang, ok := itf.(string)
-			if ok {
-				languages = append(languages, lang)
-			} else {
-				fmt.Fprintln(os.Stderr, "Settings.ToMap(): `removeStopWords` slice doesn't only contain strings")
-			}
-		}
-		if len(languages) > 0 {
-			m["removeStopWords"] = languages
+	languages := make([]string, len(itfs))
+	for i, itf := range itfs {
+		if languages[i], ok = itf.(string); !ok {
+			return v
 		}
-
-	default:
-		fmt.Fprintf(os.Stderr, "Settings.ToMap(): Wrong type for `removeStopWords`: %v\n", reflect.TypeOf(s.RemoveStopWords))
-
 	}
-
-	return m
+	return languages
 }
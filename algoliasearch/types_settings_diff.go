@@ -0,0 +1,80 @@
+package algoliasearch
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// SettingsChange is a single setting which differs between the current and
+// the desired Settings of an index, as computed by `DiffSettings`. Before is
+// nil if the setting is not set on the index. Both values are normalized the
+// same way as by `Settings.ToMap`.
+type SettingsChange struct {
+	Setting string      `json:"setting"`
+	Before  interface{} `json:"before"`
+	After   interface{} `json:"after"`
+}
+
+// String returns the change as `setting: before -> after`, where both values
+// are JSON-encoded and an unset value is shown as `<unset>`.
+func (c SettingsChange) String() string {
+	return fmt.Sprintf("%s: %s -> %s", c.Setting, formatSettingValue(c.Before), formatSettingValue(c.After))
+}
+
+func formatSettingValue(v interface{}) string {
+	if v == nil {
+		return "<unset>"
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(data)
+}
+
+// SettingsDiff is the list of the changes between two Settings, sorted by
+// setting name. It is encoded as a JSON array of SettingsChange.
+type SettingsDiff []SettingsChange
+
+// String returns the changes, one per line. It returns an empty string if
+// there is no change.
+func (d SettingsDiff) String() string {
+	lines := make([]string, len(d))
+	for i, change := range d {
+		lines[i] = change.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ToMap returns the desired values of the changed settings, as expected by
+// `SetSettings`. The settings whose desired value is nil are reset.
+func (d SettingsDiff) ToMap() Map {
+	m := make(Map, len(d))
+	for _, change := range d {
+		m[change.Setting] = change.After
+	}
+	return m
+}
+
+// ApplyOptions controls how `Index.ApplySettings` applies the desired
+// Settings.
+type ApplyOptions struct {
+	// DryRun, if set, only computes the changes without sending them.
+	DryRun bool
+
+	// ForwardToReplicas, if set, also applies the changed settings to the
+	// replicas of the index. The changes are computed against the index only.
+	ForwardToReplicas bool
+}
+
+// ApplySettingsRes reports what `Index.ApplySettings` has changed, or would
+// have changed for a dry run.
+type ApplySettingsRes struct {
+	Changes SettingsDiff
+
+	// TaskID is the ID of the published settings task. It is zero if nothing
+	// has been sent, either because of a dry run or because there was no
+	// change.
+	TaskID int
+}
@@ -0,0 +1,36 @@
+package algoliasearch
+
+import "time"
+
+// SettingsSnapshot is a copy of the Settings of an index, taken by
+// `SetSettings` right before changing them once a SettingsSnapshotStore has
+// been set with `Client.SetSettingsSnapshotStore`. It can be restored with
+// `Index.RollbackSettings`.
+type SettingsSnapshot struct {
+	ID        string    `json:"id"`
+	IndexName string    `json:"indexName"`
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Author is the `RequestOptions.SnapshotAuthor` of the `SetSettings`
+	// call which took the snapshot. It is empty if none was given.
+	Author string `json:"author"`
+
+	Settings Settings `json:"settings"`
+}
+
+// SettingsSnapshotStore persists the SettingsSnapshot taken by a Client.
+// Implementations must be safe for concurrent use.
+// FileSettingsSnapshotStore is the default implementation, which stores each
+// snapshot in its own local file.
+type SettingsSnapshotStore interface {
+	// SaveSettingsSnapshot persists the given snapshot.
+	SaveSettingsSnapshot(snapshot SettingsSnapshot) error
+
+	// GetSettingsSnapshot returns the snapshot of the index `indexName`
+	// identified by `id`, or SettingsSnapshotNotFoundErr if there is none.
+	GetSettingsSnapshot(indexName, id string) (SettingsSnapshot, error)
+
+	// ListSettingsSnapshots returns all the snapshots of the index
+	// `indexName`, the oldest first.
+	ListSettingsSnapshots(indexName string) ([]SettingsSnapshot, error)
+}
@@ -1,13 +1,31 @@
 package algoliasearch
 
+import "context"
+
 type DeleteTaskRes struct {
 	DeletedAt string `json:"deletedAt"`
 	TaskID    int    `json:"taskID"`
+	taskWaiter
+}
+
+// Wait blocks until the deletion task is published on the index it originates
+// from, according to the WaitPolicy of the Client. It stops early if `ctx` is
+// done.
+func (r DeleteTaskRes) Wait(ctx context.Context) error {
+	return r.wait(ctx, r.TaskID)
 }
 
 type UpdateTaskRes struct {
 	TaskID    int    `json:"taskID"`
 	UpdatedAt string `json:"updatedAt"`
+	taskWaiter
+}
+
+// Wait blocks until the update task is published on the index it originates
+// from, according to the WaitPolicy of the Client. It stops early if `ctx` is
+// done.
+func (r UpdateTaskRes) Wait(ctx context.Context) error {
+	return r.wait(ctx, r.TaskID)
 }
 
 type UpdateTaskWithIDRes struct {
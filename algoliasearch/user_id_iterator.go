@@ -0,0 +1,101 @@
+package algoliasearch
+
+import "context"
+
+// UserIDIterator is the exposed structure to iterate over all the user IDs
+// assigned to the clusters of a multi-cluster application, as returned by
+// `Client.ListUserIDs`.
+type UserIDIterator struct {
+	paginator *paginator
+	userIDs   []UserID
+}
+
+// NewUserIDIterator returns a new UserIDIterator that will iterate over all
+// the user IDs of the application of the given Client.
+func NewUserIDIterator(client Client) *UserIDIterator {
+	return NewUserIDIteratorWithOptions(context.Background(), client, IteratorOptions{})
+}
+
+// NewUserIDIteratorWithOptions is the same as NewUserIDIterator but the
+// iteration stops as soon as `ctx` is done and the pages are retrieved
+// according to the given IteratorOptions.
+func NewUserIDIteratorWithOptions(ctx context.Context, client Client, options IteratorOptions) *UserIDIterator {
+	it := &UserIDIterator{}
+	it.paginator = newPaginator(ctx, options.hitsPerPage(), NoMoreUserIDsErr, func(page, hitsPerPage int) (int, bool, error) {
+		res, err := client.ListUserIDsWithRequestOptions(page, hitsPerPage, options.RequestOptions)
+		if err != nil {
+			return 0, false, err
+		}
+
+		// As the number of user IDs is not returned, the last page is the
+		// first one which is not full.
+		it.userIDs = res.UserIDs
+		return len(res.UserIDs), len(res.UserIDs) < hitsPerPage, nil
+	})
+	return it
+}
+
+// Next returns the next user ID. Every call to Next should yield a different
+// user ID with a nil error until the algoliasearch.NoMoreUserIDsErr is
+// returned which means that all the user IDs have been retrieved. If the
+// error is of a different type, it means that the iteration could not have
+// been done correctly.
+func (it *UserIDIterator) Next() (*UserID, error) {
+	pos, err := it.paginator.next()
+	if err != nil {
+		return nil, err
+	}
+
+	userID := it.userIDs[pos]
+	return &userID, nil
+}
+
+// UserIDSearchIterator is the exposed structure to iterate over all the user
+// IDs matching a query, as returned by `Client.SearchUserIDs`.
+type UserIDSearchIterator struct {
+	paginator *paginator
+	hits      []UserIDHit
+}
+
+// NewUserIDSearchIterator returns a new UserIDSearchIterator that will
+// iterate over all the user IDs matching the given `query` and `params`.
+func NewUserIDSearchIterator(client Client, query string, params Map) *UserIDSearchIterator {
+	return NewUserIDSearchIteratorWithOptions(context.Background(), client, query, params, IteratorOptions{})
+}
+
+// NewUserIDSearchIteratorWithOptions is the same as NewUserIDSearchIterator
+// but the iteration stops as soon as `ctx` is done and the pages are
+// retrieved according to the given IteratorOptions.
+func NewUserIDSearchIteratorWithOptions(ctx context.Context, client Client, query string, params Map, options IteratorOptions) *UserIDSearchIterator {
+	it := &UserIDSearchIterator{}
+	it.paginator = newPaginator(ctx, options.hitsPerPage(), NoMoreUserIDsErr, func(page, hitsPerPage int) (int, bool, error) {
+		pageParams := duplicateMap(params)
+		pageParams["page"] = page
+		pageParams["hitsPerPage"] = hitsPerPage
+
+		res, err := client.SearchUserIDsWithRequestOptions(query, pageParams, options.RequestOptions)
+		if err != nil {
+			return 0, false, err
+		}
+
+		it.hits = res.Hits
+		return len(res.Hits), (page+1)*hitsPerPage >= res.NbHits, nil
+	})
+	return it
+}
+
+// Next returns the next matching user ID. Every call to Next should yield a
+// different user ID with a nil error until the algoliasearch.NoMoreUserIDsErr
+// is returned which means that all the matching user IDs have been retrieved.
+// If the error is of a different type, it means that the iteration could not
+// have been done correctly.
+func (it *UserIDSearchIterator) Next() (*UserIDHit, error) {
+	pos, err := it.paginator.next()
+	if err != nil {
+		return nil, err
+	}
+
+	hit := it.hits[pos]
+	hit.HighlightResult = nil
+	return &hit, nil
+}
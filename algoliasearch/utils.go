@@ -47,6 +47,8 @@ func encodeMap(params Map) string {
 				values.Add(k, strconv.FormatFloat(v, 'f', -1, 64))
 			case int:
 				values.Add(k, strconv.Itoa(v))
+			case paramStringer:
+				values.Add(k, v.paramString())
 			default:
 				jsonValue, _ := json.Marshal(v)
 				values.Add(k, string(jsonValue[:]))
@@ -0,0 +1,77 @@
+package algoliasearch
+
+import (
+	"fmt"
+	"os"
+)
+
+// ValidationMode controls what a Client does with the unknown keys and the
+// invalid values it detects in the settings and query parameters before
+// sending them.
+type ValidationMode int
+
+const (
+	// LenientValidation only rejects the known keys whose values are not of
+	// the expected type. Unknown keys are sent as-is. This is the default.
+	LenientValidation ValidationMode = iota
+
+	// WarnValidation also detects the unknown keys, suggesting the closest
+	// known one, and the values outside of their domain, such as an invalid
+	// `queryType` or `customRanking` criterion. They are reported through
+	// `ValidationPolicy.Warn` but the request is still sent.
+	WarnValidation
+
+	// StrictValidation rejects the unknown keys and the values outside of
+	// their domain, which are detected as with WarnValidation, without
+	// sending the request.
+	StrictValidation
+)
+
+// ValidationPolicy controls how strictly a Client validates the settings and
+// query parameters before sending them.
+type ValidationPolicy struct {
+	Mode ValidationMode
+
+	// Warn is called with each issue detected in WarnValidation mode. If nil,
+	// the issues are written to the standard error.
+	Warn func(err error)
+}
+
+// checkSettings checks the types of the given settings and, depending on the
+// ValidationPolicy of the client, their keys and values.
+func (c *client) checkSettings(settings Map) error {
+	if err := checkSettings(settings); err != nil {
+		return err
+	}
+	return c.validationPolicy.handle(checkSettingsStrictly(settings))
+}
+
+// checkQuery checks the types of the given query parameters and, depending
+// on the ValidationPolicy of the client, their keys and values.
+func (c *client) checkQuery(query Map) error {
+	if err := checkQuery(query); err != nil {
+		return err
+	}
+	return c.validationPolicy.handle(checkQueryStrictly(query))
+}
+
+// handle returns the given validation issue if the policy is strict, reports
+// it if the policy only warns and ignores it otherwise.
+func (p ValidationPolicy) handle(issue error) error {
+	if issue == nil {
+		return nil
+	}
+
+	switch p.Mode {
+	case StrictValidation:
+		return issue
+	case WarnValidation:
+		if p.Warn != nil {
+			p.Warn(issue)
+		} else {
+			fmt.Fprintf(os.Stderr, "algoliasearch: %s\n", issue)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,225 @@
+package algoliasearch
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WaitPolicy controls how `WaitTask` polls the status of a task until it gets
+// published.
+type WaitPolicy struct {
+	// InitialInterval is the upper bound of the sleep duration between the
+	// first two polls. The bound is then doubled after each poll.
+	InitialInterval time.Duration
+
+	// MaxInterval caps the upper bound of the sleep duration between two
+	// polls.
+	MaxInterval time.Duration
+
+	// Jitter is the fraction (between 0 and 1) of each interval which is
+	// randomized. With a Jitter of 0, the sleep duration is exactly the current
+	// interval while with a Jitter of 1, it is randomly picked between 0 and
+	// the current interval.
+	Jitter float64
+
+	// Timeout is the maximum overall duration to wait for a single task. Once
+	// exceeded, `WaitTaskTimeoutErr` is returned. A zero value means that the
+	// wait is never interrupted.
+	Timeout time.Duration
+}
+
+// DefaultWaitPolicy is the WaitPolicy used by any newly created Client: the
+// sleep duration between two polls is randomly picked with an upper bound
+// starting at 1s and doubling at each poll, up to 10min, without any overall
+// timeout.
+var DefaultWaitPolicy = WaitPolicy{
+	InitialInterval: time.Second,
+	MaxInterval:     10 * time.Minute,
+	Jitter:          1,
+	Timeout:         0,
+}
+
+// normalize returns a copy of the WaitPolicy where invalid or missing fields
+// are replaced with sensible values.
+func (p WaitPolicy) normalize() WaitPolicy {
+	if p.InitialInterval <= 0 {
+		p.InitialInterval = DefaultWaitPolicy.InitialInterval
+	}
+
+	if p.MaxInterval < p.InitialInterval {
+		p.MaxInterval = p.InitialInterval
+	}
+
+	if p.Jitter < 0 {
+		p.Jitter = 0
+	} else if p.Jitter > 1 {
+		p.Jitter = 1
+	}
+
+	if p.Timeout < 0 {
+		p.Timeout = 0
+	}
+
+	return p
+}
+
+// sleepDuration returns the time to sleep before the next poll, according to
+// the current `interval` and the policy's jitter.
+func (p WaitPolicy) sleepDuration(interval time.Duration) time.Duration {
+	randomized := time.Duration(p.Jitter * float64(interval))
+	if randomized <= 0 {
+		return interval
+	}
+	return interval - randomized + randDuration(randomized)
+}
+
+// nextInterval returns the interval following `interval`, bounded by the
+// policy's MaxInterval.
+func (p WaitPolicy) nextInterval(interval time.Duration) time.Duration {
+	interval *= 2
+	if interval > p.MaxInterval {
+		interval = p.MaxInterval
+	}
+	return interval
+}
+
+// IndexedTask identifies an indexing task along with the name of the index it
+// belongs to.
+type IndexedTask struct {
+	IndexName string
+	TaskID    int
+}
+
+// WaitTasksErr is the error returned by `WaitTasks` when at least one of the
+// tasks could not be waited for successfully. `Errs` holds the individual
+// error of each failing task.
+type WaitTasksErr struct {
+	Errs map[IndexedTask]error
+}
+
+func (e *WaitTasksErr) Error() string {
+	var msgs []string
+	for task, err := range e.Errs {
+		msgs = append(msgs, fmt.Sprintf("task %d of index %s: %s", task.TaskID, task.IndexName, err))
+	}
+	sort.Strings(msgs)
+	return fmt.Sprintf("%d task(s) could not be waited for: %s", len(e.Errs), strings.Join(msgs, "; "))
+}
+
+// waitTask polls the status of the task until it gets published, the policy
+// timeout is reached or the context is done.
+func (c *client) waitTask(ctx context.Context, indexName string, taskID int, opts *RequestOptions) error {
+	policy := c.waitPolicy.normalize()
+
+	waitCtx := ctx
+	if policy.Timeout > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, policy.Timeout)
+		defer cancel()
+	}
+
+	// Each poll is bound to the wait context so that a hanging status request
+	// cannot outlive the policy timeout or the caller's context.
+	pollOpts := opts.withContext(waitCtx)
+
+	interval := policy.InitialInterval
+
+	for attempt := 1; ; attempt++ {
+		res, err := c.GetStatusWithRequestOptions(indexName, taskID, pollOpts)
+		if err != nil {
+			if waitCtx.Err() != nil {
+				return waitError(ctx)
+			}
+			return err
+		}
+
+		debug("* WAIT TASK index=%s taskID=%d attempt=%d status=%s", indexName, taskID, attempt, res.Status)
+
+		if res.Status == "published" {
+			return nil
+		}
+
+		timer := time.NewTimer(policy.sleepDuration(interval))
+		select {
+		case <-waitCtx.Done():
+			timer.Stop()
+			return waitError(ctx)
+		case <-timer.C:
+		}
+
+		interval = policy.nextInterval(interval)
+	}
+}
+
+// waitError returns the error of a wait interrupted by its context: the
+// error of the caller's context `ctx` if it is done, or WaitTaskTimeoutErr if
+// only the policy timeout has been reached.
+func waitError(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return WaitTaskTimeoutErr
+}
+
+// waitTasks waits concurrently for all the given tasks and aggregates the
+// errors, if any, into a WaitTasksErr.
+func (c *client) waitTasks(ctx context.Context, tasks []IndexedTask, opts *RequestOptions) error {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	errs := make(map[IndexedTask]error)
+
+	for _, task := range tasks {
+		wg.Add(1)
+		go func(task IndexedTask) {
+			defer wg.Done()
+			if err := c.waitTask(ctx, task.IndexName, task.TaskID, opts); err != nil {
+				mu.Lock()
+				errs[task] = err
+				mu.Unlock()
+			}
+		}(task)
+	}
+
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return &WaitTasksErr{Errs: errs}
+	}
+
+	return nil
+}
+
+// taskWaiter is embedded in the responses of the write operations so that they
+// remember the Client, index and RequestOptions they originate from. This is
+// what lets such responses expose a `Wait` method.
+type taskWaiter struct {
+	client    *client
+	indexName string
+	opts      *RequestOptions
+}
+
+// bind records the Client, index name and RequestOptions which should be used
+// to wait for the task of the response.
+//
+// The Context of the RequestOptions only bounds the write operation itself:
+// it is replaced by the one given to `Wait`, so that an expired or cancelled
+// write context does not prevent the task from being waited for.
+func (w *taskWaiter) bind(c *client, indexName string, opts *RequestOptions) {
+	w.client = c
+	w.indexName = indexName
+	w.opts = opts
+}
+
+// wait blocks until the task identified by `taskID` is published. A
+// `NotAwaitableTaskErr` is returned if the response has not been returned by a
+// Client.
+func (w taskWaiter) wait(ctx context.Context, taskID int) error {
+	if w.client == nil {
+		return NotAwaitableTaskErr
+	}
+	return w.client.waitTask(ctx, w.indexName, taskID, w.opts.withContext(ctx))
+}
@@ -0,0 +1,176 @@
+package algoliasearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitPolicy_normalize(t *testing.T) {
+	require.Equal(t, WaitPolicy{time.Second, time.Second, 0, 0}, WaitPolicy{}.normalize())
+	require.Equal(t, WaitPolicy{time.Second, time.Second, 1, 0}, WaitPolicy{Jitter: 2, Timeout: -1}.normalize())
+	require.Equal(t, WaitPolicy{time.Minute, time.Minute, 0, time.Hour}, WaitPolicy{time.Minute, time.Second, -1, time.Hour}.normalize())
+	require.Equal(t, DefaultWaitPolicy, DefaultWaitPolicy.normalize())
+}
+
+func TestWaitPolicy_sleepDuration(t *testing.T) {
+	p := WaitPolicy{Jitter: 0}
+	require.Equal(t, time.Second, p.sleepDuration(time.Second))
+
+	for _, jitter := range []float64{0.25, 0.5, 1} {
+		p = WaitPolicy{Jitter: jitter}
+		for i := 0; i < 100; i++ {
+			d := p.sleepDuration(time.Second)
+			require.True(t, d > time.Duration((1-jitter)*float64(time.Second)), "sleep duration %s is too short for jitter %f", d, jitter)
+			require.True(t, d <= time.Second, "sleep duration %s is too long for jitter %f", d, jitter)
+		}
+	}
+}
+
+func TestWaitPolicy_nextInterval(t *testing.T) {
+	p := WaitPolicy{InitialInterval: time.Second, MaxInterval: 5 * time.Second}
+
+	interval := p.InitialInterval
+	var intervals []time.Duration
+	for i := 0; i < 5; i++ {
+		interval = p.nextInterval(interval)
+		intervals = append(intervals, interval)
+	}
+
+	require.Equal(t, []time.Duration{2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second, 5 * time.Second}, intervals)
+}
+
+func TestWaitTasksErr(t *testing.T) {
+	err := &WaitTasksErr{
+		Errs: map[IndexedTask]error{
+			IndexedTask{"index2", 2}: WaitTaskTimeoutErr,
+			IndexedTask{"index1", 1}: errors.New("some error"),
+		},
+	}
+
+	require.Equal(t, "2 task(s) could not be waited for: task 1 of index index1: some error; task 2 of index index2: "+WaitTaskTimeoutErr.Error(), err.Error())
+}
+
+func TestMultipleBatchRes_Tasks(t *testing.T) {
+	res := MultipleBatchRes{TaskID: map[string]int{"b": 2, "a": 1, "c": 3}}
+
+	require.Equal(t, []IndexedTask{{"a", 1}, {"b", 2}, {"c", 3}}, res.Tasks())
+	require.Empty(t, MultipleBatchRes{}.Tasks())
+}
+
+func TestAwaitableResponses_notFromClient(t *testing.T) {
+	ctx := context.Background()
+
+	require.Equal(t, NotAwaitableTaskErr, UpdateTaskRes{TaskID: 42}.Wait(ctx))
+	require.Equal(t, NotAwaitableTaskErr, UpdateTaskWithIDRes{}.Wait(ctx))
+	require.Equal(t, NotAwaitableTaskErr, DeleteTaskRes{}.Wait(ctx))
+	require.Equal(t, NotAwaitableTaskErr, BatchRes{}.Wait(ctx))
+	require.Equal(t, NotAwaitableTaskErr, MultipleBatchRes{}.Wait(ctx))
+	require.Equal(t, NotAwaitableTaskErr, SaveRuleRes{}.Wait(ctx))
+	require.Equal(t, NotAwaitableTaskErr, BatchRulesRes{}.Wait(ctx))
+	require.Equal(t, NotAwaitableTaskErr, ABTestTaskRes{}.Wait(ctx))
+
+	var res UpdateTaskRes
+	require.NoError(t, json.Unmarshal([]byte(`{"taskID":42,"updatedAt":"now"}`), &res))
+	require.Equal(t, 42, res.TaskID)
+
+	data, err := json.Marshal(res)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"taskID":42,"updatedAt":"now"}`, string(data))
+}
+
+func TestAwaitableResponses(t *testing.T) {
+	t.Parallel()
+	c, i := initClientAndIndex(t, "TestAwaitableResponses")
+	defer c.DeleteIndex("TestAwaitableResponses_move")
+
+	ctx := context.Background()
+
+	t.Log("TestAwaitableResponses: Add objects")
+	{
+		res, err := i.AddObjects([]Object{{"objectID": "one"}, {"objectID": "two"}})
+		require.NoError(t, err)
+		require.NoError(t, res.Wait(ctx))
+	}
+
+	t.Log("TestAwaitableResponses: Save rule")
+	{
+		res, err := i.SaveRule(Rule{
+			ObjectID:    "rule",
+			Condition:   NewSimpleRuleCondition(Contains, "coffee"),
+			Consequence: RuleConsequence{Params: Map{"query": "tea"}},
+		}, false)
+		require.NoError(t, err)
+		require.NoError(t, res.Wait(ctx))
+	}
+
+	t.Log("TestAwaitableResponses: Save synonym")
+	{
+		res, err := i.SaveSynonym(NewSynonym("synonym", []string{"coffee", "tea"}), false)
+		require.NoError(t, err)
+		require.NoError(t, res.Wait(ctx))
+	}
+
+	t.Log("TestAwaitableResponses: Move index")
+	{
+		res, err := i.Move("TestAwaitableResponses_move")
+		require.NoError(t, err)
+		require.NoError(t, res.Wait(ctx))
+	}
+
+	t.Log("TestAwaitableResponses: Check moved content")
+	{
+		moved := c.InitIndex("TestAwaitableResponses_move")
+		_, err := moved.GetRule("rule")
+		require.NoError(t, err)
+		_, err = moved.GetSynonym("synonym")
+		require.NoError(t, err)
+	}
+}
+
+func TestWaitTask_hangingStatusRequest(t *testing.T) {
+	rt := &blockingRoundTripper{received: make(chan struct{}, 10)}
+	c := NewClient("appID", "apiKey")
+	c.SetHTTPClient(&http.Client{Transport: rt})
+
+	t.Log("TestWaitTask_hangingStatusRequest: Interrupt the poll once the policy timeout is reached")
+	{
+		c.SetWaitPolicy(WaitPolicy{Timeout: 50 * time.Millisecond})
+		start := time.Now()
+		require.Equal(t, WaitTaskTimeoutErr, c.WaitTask("index", 42))
+		require.True(t, time.Since(start) < DefaultReadTimeout)
+	}
+
+	t.Log("TestWaitTask_hangingStatusRequest: Interrupt the poll once the caller's context is done")
+	{
+		c.SetWaitPolicy(DefaultWaitPolicy)
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			<-rt.received
+			cancel()
+		}()
+		require.Equal(t, context.Canceled, c.WaitTaskWithRequestOptions("index", 42, &RequestOptions{Context: ctx}))
+	}
+}
+
+func TestAwaitableResponses_cancelledWriteContext(t *testing.T) {
+	rt := &routingRoundTripper{responses: map[string]string{
+		"PUT /1/indexes/products/settings": `{"taskID": 1}`,
+		"GET /1/indexes/products/task/1":   `{"status": "published"}`,
+	}}
+	c := NewClient("appID", "apiKey")
+	c.SetHTTPClient(&http.Client{Transport: rt})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	res, err := c.InitIndex("products").SetSettingsWithRequestOptions(Map{"hitsPerPage": 10}, &RequestOptions{Context: ctx})
+	require.NoError(t, err)
+	cancel()
+
+	require.NoError(t, res.Wait(context.Background()))
+	require.Equal(t, "GET /1/indexes/products/task/1", rt.requests[len(rt.requests)-1])
+}